@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/config"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// Report prints the cycle and lead times of the backlog items completed in the
+// last days, with their percentiles per work item type
+func Report(args []string) error {
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	days := flags.Int("days", 30, "number of days back to look for completed items")
+	area := flags.String("area", "", "only items under this area path")
+	asCSV := flags.Bool("csv", false, "print the items as CSV")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *days < 1 {
+		return fmt.Errorf("-days must be at least 1")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	client, err := newClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Without state metadata the default process states are used
+	statesByType, err := client.GetAllWorkItemTypeStates()
+	if err != nil {
+		statesByType = nil
+	}
+
+	now := time.Now()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -*days+1)
+	timings, err := client.GetItemTimings(*area, since, statesByType)
+	if err != nil {
+		return fmt.Errorf("failed to load completed items: %w", err)
+	}
+
+	if *asCSV {
+		return writeTimingsCSV(timings)
+	}
+
+	fmt.Printf("%d items completed since %s\n\n", len(timings), since.Format("2006-01-02"))
+	if len(timings) == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tITEMS\tCYCLE 50%\t85%\t95%\tLEAD 50%\t85%\t95%")
+	for _, s := range models.TimingStatsByType(timings) {
+		typ := string(s.Type)
+		if typ == "" {
+			typ = "All types"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n", typ, s.Items,
+			models.FormatDays(s.Cycle.P50), models.FormatDays(s.Cycle.P85), models.FormatDays(s.Cycle.P95),
+			models.FormatDays(s.Lead.P50), models.FormatDays(s.Lead.P85), models.FormatDays(s.Lead.P95))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTYPE\tCOMPLETED\tCYCLE\tLEAD\tTITLE")
+	for _, t := range timings {
+		cycle := "-"
+		if t.HasCycleTime() {
+			cycle = models.FormatDays(t.CycleTime())
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", t.Item.ID, t.Item.Type,
+			t.Completed.Local().Format("2006-01-02"), cycle, models.FormatDays(t.LeadTime()), t.Item.Title)
+	}
+	return w.Flush()
+}
+
+// writeTimingsCSV prints the timings as CSV, with times in days
+func writeTimingsCSV(timings []models.ItemTiming) error {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{"id", "type", "title", "created", "started", "completed", "cycle_days", "lead_days"})
+	for _, t := range timings {
+		started, cycle := "", ""
+		if t.HasCycleTime() {
+			started = t.Started.Format(time.RFC3339)
+			cycle = strings.TrimSuffix(models.FormatDays(t.CycleTime()), "d")
+		}
+		w.Write([]string{
+			strconv.Itoa(t.Item.ID),
+			string(t.Item.Type),
+			t.Item.Title,
+			t.Created.Format(time.RFC3339),
+			started,
+			t.Completed.Format(time.RFC3339),
+			cycle,
+			strings.TrimSuffix(models.FormatDays(t.LeadTime()), "d"),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// ExecuteReport runs the report command
+func ExecuteReport(args []string) {
+	if err := Report(args); err != nil {
+		if err == flag.ErrHelp {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
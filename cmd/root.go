@@ -25,28 +25,13 @@ func Execute() error {
 		return fmt.Errorf("configuration error: %w", err)
 	}
 
-	// Handle authentication
-	var client *api.Client
-
-	if cfg.NeedsOAuth() {
-		// No PAT provided, use OAuth device flow
-		authenticator := auth.NewDeviceFlowAuthenticator()
-
-		token, err := authenticator.GetToken()
-		if err != nil {
-			return fmt.Errorf("authentication failed: %w", err)
-		}
-
-		// Create client with OAuth token
-		cfg.SetAccessToken(token)
-		client = api.NewClientWithToken(cfg, token, false)
-	} else {
-		// PAT provided, use it directly
-		client = api.NewClient(cfg)
+	client, err := newClient(cfg)
+	if err != nil {
+		return err
 	}
 
 	// Create and run the TUI
-	app := ui.NewApp(client)
+	app := ui.NewApp(client, cfg)
 
 	p := tea.NewProgram(
 		app,
@@ -60,3 +45,29 @@ func Execute() error {
 
 	return nil
 }
+
+// newClient creates an API client, authenticating with the device flow if no
+// PAT is configured
+func newClient(cfg *config.Config) (*api.Client, error) {
+	if cfg.NeedsOAuth() && cfg.IsOnPrem() {
+		// Device flow only works against Microsoft Entra ID, which on-prem servers don't use
+		return nil, fmt.Errorf("a PAT is required for Azure DevOps Server (set pat in config or AZURE_DEVOPS_PAT)")
+	}
+
+	if !cfg.NeedsOAuth() {
+		// PAT provided, use it directly
+		return api.NewClient(cfg), nil
+	}
+
+	// No PAT provided, use OAuth device flow
+	authenticator := auth.NewDeviceFlowAuthenticator()
+
+	token, err := authenticator.GetToken()
+	if err != nil {
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+
+	// Create client with OAuth token
+	cfg.SetAccessToken(token)
+	return api.NewClientWithToken(cfg, token, false), nil
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"sort"
 	"strings"
 
@@ -19,8 +20,13 @@ type classificationNode struct {
 
 // GetAreas fetches all areas for the project
 func (c *Client) GetAreas() ([]models.Area, error) {
+	return c.GetAreasContext(context.Background())
+}
+
+// GetAreasContext is like GetAreas but honors ctx cancellation
+func (c *Client) GetAreasContext(ctx context.Context) ([]models.Area, error) {
 	// Use the classification nodes API with depth to get area hierarchy
-	resp, err := c.get("/wit/classificationnodes/areas?$depth=10")
+	resp, err := c.get(ctx, "/wit/classificationnodes/areas?$depth=10")
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// attachmentRel is the relation type of attached files
+const attachmentRel = "AttachedFile"
+
+// attachmentReference is the response of an attachment upload
+type attachmentReference struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// parseAttachment converts an AttachedFile relation into an attachment
+func parseAttachment(rel workItemRelation) models.Attachment {
+	att := models.Attachment{URL: rel.URL}
+	att.Name, _ = rel.Attributes["name"].(string)
+	att.Comment, _ = rel.Attributes["comment"].(string)
+	if size, ok := rel.Attributes["resourceSize"].(float64); ok {
+		att.Size = int64(size)
+	}
+	for _, attr := range []string{"authorizedDate", "resourceCreatedDate"} {
+		if s, ok := rel.Attributes[attr].(string); ok {
+			if t, err := time.Parse(time.RFC3339, s); err == nil {
+				att.AddedDate = t
+				break
+			}
+		}
+	}
+	return att
+}
+
+// GetAttachmentUploaders returns who added each attachment of a work item, keyed by
+// attachment URL. It's taken from the work item's updates since the relation itself
+// doesn't record it.
+func (c *Client) GetAttachmentUploaders(id int) (map[string]string, error) {
+	return c.GetAttachmentUploadersContext(context.Background(), id)
+}
+
+// GetAttachmentUploadersContext is like GetAttachmentUploaders but honors ctx cancellation
+func (c *Client) GetAttachmentUploadersContext(ctx context.Context, id int) (map[string]string, error) {
+	updates, err := c.getUpdates(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	uploaders := make(map[string]string)
+	for _, u := range updates {
+		if u.Relations == nil {
+			continue
+		}
+		for _, r := range u.Relations.Added {
+			if r.Rel == attachmentRel {
+				uploaders[r.URL] = u.RevisedBy.DisplayName
+			}
+		}
+	}
+
+	return uploaders, nil
+}
+
+// DownloadAttachment writes the content of an attachment to w
+func (c *Client) DownloadAttachment(att models.Attachment, w io.Writer) error {
+	return c.DownloadAttachmentContext(context.Background(), att, w)
+}
+
+// DownloadAttachmentContext is like DownloadAttachment but honors ctx cancellation
+func (c *Client) DownloadAttachmentContext(ctx context.Context, att models.Attachment, w io.Writer) error {
+	u := fmt.Sprintf("%s?fileName=%s&download=true", att.URL, url.QueryEscape(att.Name))
+	resp, err := c.doTransfer(ctx, "GET", u, nil, "application/octet-stream", true)
+	if err != nil {
+		return fmt.Errorf("downloading %s: %w", att.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("downloading %s: %w", att.Name, err)
+	}
+	return nil
+}
+
+// UploadAttachment uploads a local file and attaches it to a work item
+func (c *Client) UploadAttachment(id int, path string) error {
+	return c.UploadAttachmentContext(context.Background(), id, path)
+}
+
+// UploadAttachmentContext is like UploadAttachment but honors ctx cancellation
+func (c *Client) UploadAttachmentContext(ctx context.Context, id int, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	name := filepath.Base(path)
+
+	// Stream the file rather than reading it into memory
+	content := io.NewSectionReader(f, 0, info.Size())
+	endpoint := fmt.Sprintf("/wit/attachments?fileName=%s", url.QueryEscape(name))
+	resp, err := c.doTransfer(ctx, "POST", buildURL(c.baseURL, endpoint), content, "application/octet-stream", false)
+	if err != nil {
+		return fmt.Errorf("uploading %s: %w", name, err)
+	}
+
+	var ref attachmentReference
+	if err := decode(resp, &ref); err != nil {
+		return err
+	}
+
+	rev, _, err := c.getRelations(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	ops := []map[string]interface{}{{
+		"op":   "add",
+		"path": "/relations/-",
+		"value": map[string]interface{}{
+			"rel": attachmentRel,
+			"url": ref.URL,
+			"attributes": map[string]interface{}{
+				"name": name,
+			},
+		},
+	}}
+	if err := c.patchRelations(ctx, id, rev, ops); err != nil {
+		return fmt.Errorf("attaching %s to #%d: %w", name, id, err)
+	}
+	return nil
+}
+
+// SaveAttachment downloads an attachment into dir and returns the path of the file
+func (c *Client) SaveAttachment(att models.Attachment, dir string) (string, error) {
+	return c.SaveAttachmentContext(context.Background(), att, dir)
+}
+
+// SaveAttachmentContext is like SaveAttachment but honors ctx cancellation
+func (c *Client) SaveAttachmentContext(ctx context.Context, att models.Attachment, dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", dir, err)
+	}
+
+	path := attachmentFileName(dir, att.Name)
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("creating %s: %w", path, err)
+	}
+
+	if err := c.DownloadAttachmentContext(ctx, att, f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("writing %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// attachmentFileName returns a path in dir for saving an attachment, adding a
+// numeric suffix if a file with that name already exists
+func attachmentFileName(dir, name string) string {
+	if name == "" {
+		name = "attachment"
+	}
+	name = filepath.Base(name)
+	path := filepath.Join(dir, name)
+	ext := filepath.Ext(name)
+	stem := name[:len(name)-len(ext)]
+	for i := 1; ; i++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return path
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s (%d)%s", stem, i, ext))
+	}
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// MaxBatchSize is the maximum number of work item updates per batch request
+const MaxBatchSize = 200
+
+// BatchUpdate is a field update of one work item in a batch
+type BatchUpdate struct {
+	ID     int
+	Rev    int // Revision the change is based on; 0 skips the concurrency check
+	Fields map[string]interface{}
+}
+
+// BatchResult is the outcome of one update in a batch
+type BatchResult struct {
+	ID  int
+	Err error // nil if the update succeeded
+}
+
+// batchRequest is one sub-request of a $batch call
+type batchRequest struct {
+	Method  string                   `json:"method"`
+	URI     string                   `json:"uri"`
+	Headers map[string]string        `json:"headers"`
+	Body    []map[string]interface{} `json:"body"`
+}
+
+type batchResponse struct {
+	Count int `json:"count"`
+	Value []struct {
+		Code int    `json:"code"`
+		Body string `json:"body"` // JSON encoded work item or error
+	} `json:"value"`
+}
+
+// UpdateWorkItemsBatch applies field updates to several work items with the batch
+// API. The batch is not transactional: each update succeeds or fails on its own and
+// is reported in the results, in the order of updates. The error is only set if the
+// batch request itself failed.
+func (c *Client) UpdateWorkItemsBatch(updates []BatchUpdate) ([]BatchResult, error) {
+	return c.UpdateWorkItemsBatchContext(context.Background(), updates)
+}
+
+// UpdateWorkItemsBatchContext is like UpdateWorkItemsBatch but honors ctx cancellation
+func (c *Client) UpdateWorkItemsBatchContext(ctx context.Context, updates []BatchUpdate) ([]BatchResult, error) {
+	if len(updates) > MaxBatchSize {
+		return nil, fmt.Errorf("batch of %d updates exceeds the limit of %d", len(updates), MaxBatchSize)
+	}
+
+	version := c.resolveVersion(apiVersion)
+	requests := make([]batchRequest, 0, len(updates))
+	for _, u := range updates {
+		ops := make([]map[string]interface{}, 0, len(u.Fields)+1)
+		if u.Rev > 0 {
+			ops = append(ops, map[string]interface{}{
+				"op":    "test",
+				"path":  "/rev",
+				"value": u.Rev,
+			})
+		}
+		ops = append(ops, fieldPatchOps(u.Fields)...)
+
+		requests = append(requests, batchRequest{
+			Method:  "PATCH",
+			URI:     fmt.Sprintf("/_apis/wit/workitems/%d?api-version=%s", u.ID, version),
+			Headers: map[string]string{"Content-Type": "application/json-patch+json"},
+			Body:    ops,
+		})
+	}
+
+	bodyBytes, err := json.Marshal(requests)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling batch request: %w", err)
+	}
+
+	resp, err := c.doVersioned(ctx, "POST", c.collectionURL+"/_apis/wit/$batch", apiVersion, bytes.NewReader(bodyBytes), "application/json", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch batchResponse
+	if err := decode(resp, &batch); err != nil {
+		return nil, err
+	}
+	if len(batch.Value) != len(updates) {
+		return nil, fmt.Errorf("batch returned %d results for %d updates", len(batch.Value), len(updates))
+	}
+
+	results := make([]BatchResult, len(updates))
+	for i, v := range batch.Value {
+		results[i].ID = updates[i].ID
+		if v.Code < 200 || v.Code >= 300 {
+			results[i].Err = newAPIError(v.Code, http.Header{}, []byte(v.Body))
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+type boardsResponse struct {
+	Count int `json:"count"`
+	Value []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"value"`
+}
+
+type boardFieldRef struct {
+	ReferenceName string `json:"referenceName"`
+}
+
+type boardAPIItem struct {
+	ID      string               `json:"id"`
+	Name    string               `json:"name"`
+	Columns []models.BoardColumn `json:"columns"`
+	Rows    []boardRowAPIItem    `json:"rows"`
+	Fields  struct {
+		ColumnField boardFieldRef `json:"columnField"`
+		DoneField   boardFieldRef `json:"doneField"`
+		RowField    boardFieldRef `json:"rowField"`
+	} `json:"fields"`
+}
+
+type boardRowAPIItem struct {
+	ID   string  `json:"id"`
+	Name *string `json:"name"` // null for the default lane
+}
+
+// GetBoards fetches the team's Kanban boards, one per backlog level
+func (c *Client) GetBoards() ([]models.Board, error) {
+	return c.GetBoardsContext(context.Background())
+}
+
+// GetBoardsContext is like GetBoards but honors ctx cancellation
+func (c *Client) GetBoardsContext(ctx context.Context) ([]models.Board, error) {
+	resp, err := c.getTeam(ctx, "/work/boards")
+	if err != nil {
+		return nil, err
+	}
+
+	var list boardsResponse
+	if err := decode(resp, &list); err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		index int
+		board *models.Board
+		err   error
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Each board's columns and rows take their own request; fetch them at once
+	results := make(chan result, len(list.Value))
+	for i, b := range list.Value {
+		go func(i int, id string) {
+			board, err := c.getBoard(ctx, id)
+			results <- result{index: i, board: board, err: err}
+		}(i, b.ID)
+	}
+
+	boards := make([]models.Board, len(list.Value))
+	for range list.Value {
+		r := <-results
+		if r.err != nil {
+			return nil, r.err
+		}
+		boards[r.index] = *r.board
+	}
+	return boards, nil
+}
+
+// getBoard fetches the columns, swimlanes and fields of a board
+func (c *Client) getBoard(ctx context.Context, id string) (*models.Board, error) {
+	resp, err := c.getTeam(ctx, "/work/boards/"+url.PathEscape(id))
+	if err != nil {
+		return nil, err
+	}
+
+	var item boardAPIItem
+	if err := decode(resp, &item); err != nil {
+		return nil, err
+	}
+
+	board := &models.Board{
+		ID:          item.ID,
+		Name:        item.Name,
+		Columns:     item.Columns,
+		ColumnField: item.Fields.ColumnField.ReferenceName,
+		DoneField:   item.Fields.DoneField.ReferenceName,
+		RowField:    item.Fields.RowField.ReferenceName,
+	}
+	for _, r := range item.Rows {
+		row := models.BoardRow{ID: r.ID}
+		if r.Name != nil {
+			row.Name = *r.Name
+		}
+		board.Rows = append(board.Rows, row)
+	}
+	if len(board.Rows) == 0 {
+		board.Rows = []models.BoardRow{{}}
+	}
+	return board, nil
+}
+
+// MoveBoardCard moves a work item to a column of a board. The state is changed to the
+// one the column maps to if needed; done sets the Done half of split columns.
+// The move is based on item.Rev and fails with a RevisionConflictError if the item
+// changed since.
+func (c *Client) MoveBoardCard(board *models.Board, item *models.WorkItem, column int, done bool) error {
+	return c.MoveBoardCardContext(context.Background(), board, item, column, done)
+}
+
+// MoveBoardCardContext is like MoveBoardCard but honors ctx cancellation
+func (c *Client) MoveBoardCardContext(ctx context.Context, board *models.Board, item *models.WorkItem, column int, done bool) error {
+	col := board.Columns[column]
+	fields := map[string]interface{}{
+		board.ColumnField: col.Name,
+	}
+	if board.DoneField != "" {
+		fields[board.DoneField] = col.IsSplit && done
+	}
+	if state, ok := col.StateMappings[string(item.Type)]; ok && state != string(item.State) {
+		fields["System.State"] = state
+	}
+	return c.UpdateFieldsContext(ctx, item.ID, item.Rev, fields)
+}
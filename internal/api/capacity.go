@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+type dateRangeAPIItem struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+type capacityAPIItem struct {
+	TeamMember identityRef `json:"teamMember"`
+	Activities []struct {
+		CapacityPerDay float64 `json:"capacityPerDay"`
+		Name           string  `json:"name"`
+	} `json:"activities"`
+	DaysOff []dateRangeAPIItem `json:"daysOff"`
+}
+
+// capacitiesResponse is the capacity of a sprint. API version 7.1 returns the
+// members in teamMembers, earlier versions in value.
+type capacitiesResponse struct {
+	TeamMembers []capacityAPIItem `json:"teamMembers"`
+	Value       []capacityAPIItem `json:"value"`
+}
+
+type teamDaysOffResponse struct {
+	DaysOff []dateRangeAPIItem `json:"daysOff"`
+}
+
+type teamSettingsResponse struct {
+	WorkingDays []string `json:"workingDays"`
+}
+
+// GetTeamCapacity fetches the team's capacity, days off and working days for an iteration
+func (c *Client) GetTeamCapacity(iterationID string) (*models.TeamCapacity, error) {
+	return c.GetTeamCapacityContext(context.Background(), iterationID)
+}
+
+// GetTeamCapacityContext is like GetTeamCapacity but honors ctx cancellation
+func (c *Client) GetTeamCapacityContext(ctx context.Context, iterationID string) (*models.TeamCapacity, error) {
+	resp, err := c.getTeam(ctx, fmt.Sprintf("/work/teamsettings/iterations/%s/capacities", iterationID))
+	if err != nil {
+		return nil, fmt.Errorf("fetching capacity: %w", err)
+	}
+	var capResp capacitiesResponse
+	if err := decode(resp, &capResp); err != nil {
+		return nil, err
+	}
+
+	resp, err = c.getTeam(ctx, fmt.Sprintf("/work/teamsettings/iterations/%s/teamdaysoff", iterationID))
+	if err != nil {
+		return nil, fmt.Errorf("fetching team days off: %w", err)
+	}
+	var daysOffResp teamDaysOffResponse
+	if err := decode(resp, &daysOffResp); err != nil {
+		return nil, err
+	}
+
+	resp, err = c.getTeam(ctx, "/work/teamsettings")
+	if err != nil {
+		return nil, fmt.Errorf("fetching team settings: %w", err)
+	}
+	var settings teamSettingsResponse
+	if err := decode(resp, &settings); err != nil {
+		return nil, err
+	}
+
+	capacity := &models.TeamCapacity{TeamDaysOff: convertDateRanges(daysOffResp.DaysOff)}
+	for _, name := range settings.WorkingDays {
+		if day, ok := weekdays[strings.ToLower(name)]; ok {
+			capacity.WorkingDays = append(capacity.WorkingDays, day)
+		}
+	}
+
+	members := capResp.TeamMembers
+	if len(members) == 0 {
+		members = capResp.Value
+	}
+	for _, m := range members {
+		mc := models.MemberCapacity{
+			Member: models.TeamMember{
+				ID:          m.TeamMember.ID,
+				DisplayName: m.TeamMember.DisplayName,
+				UniqueName:  m.TeamMember.UniqueName,
+			},
+			DaysOff: convertDateRanges(m.DaysOff),
+		}
+		for _, a := range m.Activities {
+			mc.CapacityPerDay += a.CapacityPerDay
+		}
+		capacity.Members = append(capacity.Members, mc)
+	}
+
+	return capacity, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+func convertDateRanges(items []dateRangeAPIItem) []models.DateRange {
+	ranges := make([]models.DateRange, 0, len(items))
+	for _, r := range items {
+		ranges = append(ranges, models.DateRange{Start: r.Start, End: r.End})
+	}
+	return ranges
+}
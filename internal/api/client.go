@@ -1,11 +1,16 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/samuelenocsson/devops-tui/internal/config"
@@ -14,18 +19,38 @@ import (
 const (
 	apiVersion        = "7.1"
 	apiVersionPreview = "7.1-preview"
+	// Comments need resource version 4 for markdown support
+	apiVersionComments = "7.1-preview.4"
 )
 
+// versionOutOfRangeRe matches the server's hint when a requested api-version is too new,
+// e.g. "The latest REST API version for this server is 7.0."
+var versionOutOfRangeRe = regexp.MustCompile(`latest REST API version for this server is ([0-9]+\.[0-9]+)`)
+
 // Client is the Azure DevOps API client
 type Client struct {
-	httpClient   *http.Client
-	baseURL      string
-	teamURL      string
-	webURL       string
-	authHeader   string
-	organization string
-	project      string
-	team         string
+	httpClient    *http.Client
+	collectionURL string
+	baseURL       string
+	teamURL       string
+	webURL        string
+	authHeader    string
+	organization  string
+	project       string
+	team          string
+
+	// versionMu guards serverVersion, which is negotiated on first use against on-prem servers
+	versionMu     sync.Mutex
+	serverVersion string
+
+	// authMu guards authHeader, which is replaced when the user signs in again
+	authMu sync.RWMutex
+
+	// transferClient moves attachment content. It has no overall timeout, since
+	// large files take longer; transfers rely on their context instead.
+	transferClient *http.Client
+
+	rateLimit rateLimitTracker
 }
 
 // NewClient creates a new Azure DevOps API client
@@ -41,18 +66,7 @@ func NewClient(cfg *config.Config) *Client {
 		authHeader = "Bearer " + cfg.GetToken()
 	}
 
-	return &Client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		baseURL:      cfg.BaseURL(),
-		teamURL:      cfg.TeamURL(),
-		webURL:       cfg.WebURL(),
-		authHeader:   authHeader,
-		organization: cfg.Organization,
-		project:      cfg.Project,
-		team:         cfg.Team,
-	}
+	return newClient(cfg, authHeader)
 }
 
 // NewClientWithToken creates a new Azure DevOps API client with a specific token
@@ -69,131 +83,262 @@ func NewClientWithToken(cfg *config.Config, token string, isPAT bool) *Client {
 		authHeader = "Bearer " + token
 	}
 
-	return &Client{
+	return newClient(cfg, authHeader)
+}
+
+// newClient builds a client for the configured server with a prepared auth header
+func newClient(cfg *config.Config, authHeader string) *Client {
+	version := cfg.APIVersion
+	if version == "" {
+		version = apiVersion
+	}
+
+	c := &Client{
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		baseURL:      cfg.BaseURL(),
-		teamURL:      cfg.TeamURL(),
-		webURL:       cfg.WebURL(),
-		authHeader:   authHeader,
-		organization: cfg.Organization,
-		project:      cfg.Project,
-		team:         cfg.Team,
+		collectionURL: cfg.CollectionURL(),
+		baseURL:       cfg.BaseURL(),
+		teamURL:       cfg.TeamURL(),
+		webURL:        cfg.WebURL(),
+		authHeader:    authHeader,
+		organization:  cfg.CollectionName(),
+		project:       cfg.Project,
+		team:          cfg.Team,
+		serverVersion: version,
+	}
+	c.transferClient = &http.Client{}
+	return c
+}
+
+// SetAccessToken replaces the OAuth access token, e.g. after the user signed in again
+func (c *Client) SetAccessToken(token string) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.authHeader = "Bearer " + token
+}
+
+// resolveVersion maps the requested API version onto the version negotiated with the server
+func (c *Client) resolveVersion(version string) string {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+
+	// Keep the preview suffix, including any resource version (e.g. "-preview.4")
+	if i := strings.Index(version, "-preview"); i >= 0 {
+		return c.serverVersion + version[i:]
+	}
+	return c.serverVersion
+}
+
+// downgradeVersion lowers the negotiated API version if the error reports it as out of range.
+// Returns true if the request should be retried with the new version.
+func (c *Client) downgradeVersion(err error) bool {
+	if err == nil {
+		return false
 	}
+	match := versionOutOfRangeRe.FindStringSubmatch(err.Error())
+	if match == nil {
+		return false
+	}
+
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+
+	if match[1] == c.serverVersion {
+		return false
+	}
+	c.serverVersion = match[1]
+	return true
+}
+
+// APIVersion returns the REST API version currently used against the server
+func (c *Client) APIVersion() string {
+	return c.resolveVersion(apiVersion)
 }
 
 // doRequest performs an HTTP request with authentication
-func (c *Client) doRequest(method, url string, body io.Reader) (*http.Response, error) {
-	return c.doRequestWithContentType(method, url, body, "application/json")
+func (c *Client) doRequest(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
+	return c.doRequestWithContentType(ctx, method, url, body, "application/json")
 }
 
 // doRequestWithContentType performs an HTTP request with authentication and custom content type
-func (c *Client) doRequestWithContentType(method, url string, body io.Reader, contentType string) (*http.Response, error) {
-	req, err := http.NewRequest(method, url, body)
+func (c *Client) doRequestWithContentType(ctx context.Context, method, url string, body io.Reader, contentType string) (*http.Response, error) {
+	req, err := c.newRequest(ctx, method, url, body, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.send(c.httpClient, req)
+}
+
+// newRequest creates an authenticated HTTP request
+func (c *Client) newRequest(ctx context.Context, method, url string, body io.Reader, contentType string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
+	c.authMu.RLock()
 	req.Header.Set("Authorization", c.authHeader)
+	c.authMu.RUnlock()
 	req.Header.Set("Content-Type", contentType)
+	return req, nil
+}
 
-	resp, err := c.httpClient.Do(req)
+// send performs a request with the given HTTP client, turning error statuses into errors
+func (c *Client) send(client *http.Client, req *http.Request) (*http.Response, error) {
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
 	}
 
+	c.rateLimit.update(resp.Header)
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		body, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp.StatusCode, resp.Header, body)
 	}
 
 	return resp, nil
 }
 
 // get performs a GET request to base URL
-func (c *Client) get(endpoint string) (*http.Response, error) {
-	return c.getWithBase(c.baseURL, endpoint)
+func (c *Client) get(ctx context.Context, endpoint string) (*http.Response, error) {
+	return c.getWithBase(ctx, c.baseURL, endpoint)
 }
 
 // getTeam performs a GET request to team-specific URL
-func (c *Client) getTeam(endpoint string) (*http.Response, error) {
-	return c.getWithBase(c.teamURL, endpoint)
+func (c *Client) getTeam(ctx context.Context, endpoint string) (*http.Response, error) {
+	return c.getWithBase(ctx, c.teamURL, endpoint)
 }
 
 // getWithBase performs a GET request with a specific base URL
-func (c *Client) getWithBase(baseURL, endpoint string) (*http.Response, error) {
-	return c.getWithBaseAndVersion(baseURL, endpoint, apiVersion)
+func (c *Client) getWithBase(ctx context.Context, baseURL, endpoint string) (*http.Response, error) {
+	return c.getWithBaseAndVersion(ctx, baseURL, endpoint, apiVersion)
 }
 
 // getPreview performs a GET request using preview API version
-func (c *Client) getPreview(endpoint string) (*http.Response, error) {
-	return c.getWithBaseAndVersion(c.baseURL, endpoint, apiVersionPreview)
+func (c *Client) getPreview(ctx context.Context, endpoint string) (*http.Response, error) {
+	return c.getWithBaseAndVersion(ctx, c.baseURL, endpoint, apiVersionPreview)
 }
 
 // getWithBaseAndVersion performs a GET request with a specific base URL and API version
-func (c *Client) getWithBaseAndVersion(baseURL, endpoint, version string) (*http.Response, error) {
-	url := fmt.Sprintf("%s%s", baseURL, endpoint)
-	if endpoint[0] != '/' {
-		url = fmt.Sprintf("%s/%s", baseURL, endpoint)
-	}
-
-	// Add API version
-	if len(url) > 0 {
-		separator := "?"
-		if len(url) > 0 && url[len(url)-1] != '?' {
-			for _, c := range url {
-				if c == '?' {
-					separator = "&"
-					break
-				}
-			}
-		}
-		url = fmt.Sprintf("%s%sapi-version=%s", url, separator, version)
-	}
-
-	return c.doRequest("GET", url, nil)
+func (c *Client) getWithBaseAndVersion(ctx context.Context, baseURL, endpoint, version string) (*http.Response, error) {
+	return c.doVersioned(ctx, "GET", buildURL(baseURL, endpoint), version, nil, "application/json", true)
 }
 
 // post performs a POST request
-func (c *Client) post(endpoint string, body io.Reader) (*http.Response, error) {
-	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
-	if endpoint[0] != '/' {
-		url = fmt.Sprintf("%s/%s", c.baseURL, endpoint)
+func (c *Client) post(ctx context.Context, endpoint string, body io.Reader) (*http.Response, error) {
+	return c.doVersioned(ctx, "POST", buildURL(c.baseURL, endpoint), apiVersion, body, "application/json", false)
+}
+
+// postQuery performs a read-only POST request (e.g. WIQL) that is safe to retry
+func (c *Client) postQuery(ctx context.Context, endpoint string, body io.Reader) (*http.Response, error) {
+	return c.doVersioned(ctx, "POST", buildURL(c.baseURL, endpoint), apiVersion, body, "application/json", true)
+}
+
+// patch performs a PATCH request (for work item updates)
+func (c *Client) patch(ctx context.Context, endpoint string, body io.Reader) (*http.Response, error) {
+	return c.doVersioned(ctx, "PATCH", buildURL(c.baseURL, endpoint), apiVersion, body, "application/json-patch+json", false)
+}
+
+// postPatch performs a POST request with a JSON Patch body (for work item creation)
+func (c *Client) postPatch(ctx context.Context, endpoint string, body io.Reader) (*http.Response, error) {
+	return c.doVersioned(ctx, "POST", buildURL(c.baseURL, endpoint), apiVersion, body, "application/json-patch+json", false)
+}
+
+// doVersioned performs a request with the api-version query parameter. It retries once
+// with a lower version if the server reports the requested one as out of range, and
+// retries idempotent requests on throttling and transient failures.
+func (c *Client) doVersioned(ctx context.Context, method, url, version string, body io.Reader, contentType string, idempotent bool) (*http.Response, error) {
+	// Buffer the body so it can be replayed after a version downgrade
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
 	}
 
-	// Add API version
-	separator := "?"
-	for _, ch := range url {
-		if ch == '?' {
-			separator = "&"
-			break
+	negotiated := false
+	for attempt := 0; ; attempt++ {
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+
+		resp, err := c.doRequestWithContentType(ctx, method, withAPIVersion(url, c.resolveVersion(version)), reqBody, contentType)
+		if err == nil {
+			return resp, nil
+		}
+
+		if !negotiated && c.downgradeVersion(err) {
+			negotiated = true
+			continue
+		}
+
+		if !idempotent || attempt >= maxRetries || !shouldRetry(err) {
+			return nil, err
+		}
+		if err := sleepContext(ctx, retryDelay(err, attempt)); err != nil {
+			return nil, err
 		}
 	}
-	url = fmt.Sprintf("%s%sapi-version=%s", url, separator, apiVersion)
+}
 
-	return c.doRequest("POST", url, body)
+// doTransfer performs a request moving attachment content with the transfer client.
+// Unlike doVersioned it streams body instead of buffering it; body is read from the
+// start again when the request is retried.
+func (c *Client) doTransfer(ctx context.Context, method, url string, body *io.SectionReader, contentType string, idempotent bool) (*http.Response, error) {
+	negotiated := false
+	for attempt := 0; ; attempt++ {
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = io.NewSectionReader(body, 0, body.Size())
+		}
+		req, err := c.newRequest(ctx, method, withAPIVersion(url, c.resolveVersion(apiVersion)), reqBody, contentType)
+		if err != nil {
+			return nil, err
+		}
+		if body != nil {
+			req.ContentLength = body.Size()
+		}
+
+		resp, err := c.send(c.transferClient, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		if !negotiated && c.downgradeVersion(err) {
+			negotiated = true
+			continue
+		}
+
+		if !idempotent || attempt >= maxRetries || !shouldRetry(err) {
+			return nil, err
+		}
+		if err := sleepContext(ctx, retryDelay(err, attempt)); err != nil {
+			return nil, err
+		}
+	}
 }
 
-// patch performs a PATCH request (for work item updates)
-func (c *Client) patch(endpoint string, body io.Reader) (*http.Response, error) {
-	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
-	if endpoint[0] != '/' {
-		url = fmt.Sprintf("%s/%s", c.baseURL, endpoint)
+// buildURL joins a base URL and an endpoint
+func buildURL(baseURL, endpoint string) string {
+	if endpoint != "" && endpoint[0] != '/' {
+		return fmt.Sprintf("%s/%s", baseURL, endpoint)
 	}
+	return fmt.Sprintf("%s%s", baseURL, endpoint)
+}
 
-	// Add API version
+// withAPIVersion appends the api-version query parameter to a URL
+func withAPIVersion(url, version string) string {
 	separator := "?"
-	for _, ch := range url {
-		if ch == '?' {
-			separator = "&"
-			break
-		}
+	if strings.Contains(url, "?") {
+		separator = "&"
 	}
-	url = fmt.Sprintf("%s%sapi-version=%s", url, separator, apiVersion)
-
-	return c.doRequestWithContentType("PATCH", url, body, "application/json-patch+json")
+	return fmt.Sprintf("%s%sapi-version=%s", url, separator, version)
 }
 
 // decode decodes a JSON response into the given target
@@ -212,7 +357,17 @@ func (c *Client) WorkItemWebURL(id int) string {
 	return fmt.Sprintf("%s/_workitems/edit/%d", c.webURL, id)
 }
 
-// Organization returns the organization name
+// workItemAPIURL returns the REST URL of a work item, as used in relation links
+func (c *Client) workItemAPIURL(id int) string {
+	return fmt.Sprintf("%s/wit/workItems/%d", c.baseURL, id)
+}
+
+// CollectionURL returns the organization (hosted) or collection (on-prem) URL
+func (c *Client) CollectionURL() string {
+	return c.collectionURL
+}
+
+// Organization returns the organization name (or collection name for on-prem servers)
 func (c *Client) Organization() string {
 	return c.organization
 }
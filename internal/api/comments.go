@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// commentsResponse represents the response from the comments API
+type commentsResponse struct {
+	TotalCount int              `json:"totalCount"`
+	Count      int              `json:"count"`
+	Comments   []commentAPIItem `json:"comments"`
+}
+
+type commentAPIItem struct {
+	ID           int          `json:"id"`
+	WorkItemID   int          `json:"workItemId"`
+	Text         string       `json:"text"`
+	Format       string       `json:"format"` // "markdown" or "html"
+	CreatedBy    *identityRef `json:"createdBy"`
+	CreatedDate  time.Time    `json:"createdDate"`
+	ModifiedBy   *identityRef `json:"modifiedBy"`
+	ModifiedDate time.Time    `json:"modifiedDate"`
+}
+
+// commentRequest is the body for creating or editing a comment
+type commentRequest struct {
+	Text string `json:"text"`
+}
+
+// GetWorkItemComments fetches comments for a work item
+func (c *Client) GetWorkItemComments(id int) ([]models.Comment, error) {
+	return c.GetWorkItemCommentsContext(context.Background(), id)
+}
+
+// GetWorkItemCommentsContext is like GetWorkItemComments but honors ctx cancellation
+func (c *Client) GetWorkItemCommentsContext(ctx context.Context, id int) ([]models.Comment, error) {
+	endpoint := fmt.Sprintf("/wit/workitems/%d/comments", id)
+	resp, err := c.getWithBaseAndVersion(ctx, c.baseURL, endpoint, apiVersionComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp commentsResponse
+	if err := decode(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	comments := make([]models.Comment, 0, len(apiResp.Comments))
+	for _, item := range apiResp.Comments {
+		comments = append(comments, convertComment(item))
+	}
+
+	return comments, nil
+}
+
+// AddComment posts a new markdown comment on a work item
+func (c *Client) AddComment(workItemID int, text string) (*models.Comment, error) {
+	return c.AddCommentContext(context.Background(), workItemID, text)
+}
+
+// AddCommentContext is like AddComment but honors ctx cancellation
+func (c *Client) AddCommentContext(ctx context.Context, workItemID int, text string) (*models.Comment, error) {
+	endpoint := fmt.Sprintf("/wit/workitems/%d/comments?format=markdown", workItemID)
+	return c.sendComment(ctx, "POST", endpoint, text)
+}
+
+// UpdateComment replaces the text of an existing comment
+func (c *Client) UpdateComment(workItemID, commentID int, text string) (*models.Comment, error) {
+	return c.UpdateCommentContext(context.Background(), workItemID, commentID, text)
+}
+
+// UpdateCommentContext is like UpdateComment but honors ctx cancellation
+func (c *Client) UpdateCommentContext(ctx context.Context, workItemID, commentID int, text string) (*models.Comment, error) {
+	endpoint := fmt.Sprintf("/wit/workitems/%d/comments/%d?format=markdown", workItemID, commentID)
+	return c.sendComment(ctx, "PATCH", endpoint, text)
+}
+
+// DeleteComment deletes a comment
+func (c *Client) DeleteComment(workItemID, commentID int) error {
+	return c.DeleteCommentContext(context.Background(), workItemID, commentID)
+}
+
+// DeleteCommentContext is like DeleteComment but honors ctx cancellation
+func (c *Client) DeleteCommentContext(ctx context.Context, workItemID, commentID int) error {
+	endpoint := fmt.Sprintf("/wit/workitems/%d/comments/%d", workItemID, commentID)
+	resp, err := c.doVersioned(ctx, "DELETE", buildURL(c.baseURL, endpoint), apiVersionComments, nil, "application/json", false)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// sendComment creates or edits a comment and returns the server's copy
+func (c *Client) sendComment(ctx context.Context, method, endpoint, text string) (*models.Comment, error) {
+	bodyBytes, err := json.Marshal(commentRequest{Text: text})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling comment: %w", err)
+	}
+
+	resp, err := c.doVersioned(ctx, method, buildURL(c.baseURL, endpoint), apiVersionComments, bytes.NewReader(bodyBytes), "application/json", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var item commentAPIItem
+	if err := decode(resp, &item); err != nil {
+		return nil, err
+	}
+
+	comment := convertComment(item)
+	return &comment, nil
+}
+
+// convertComment converts an API comment to our model
+func convertComment(item commentAPIItem) models.Comment {
+	comment := models.Comment{
+		ID:           item.ID,
+		Text:         item.Text,
+		Format:       item.Format,
+		CreatedDate:  item.CreatedDate,
+		ModifiedDate: item.ModifiedDate,
+	}
+	// Markdown comments are kept as-is so they can be edited and rendered
+	if item.Format != models.CommentFormatMarkdown {
+		comment.Text = stripHTML(item.Text)
+	}
+	if item.CreatedBy != nil {
+		comment.CreatedBy = item.CreatedBy.DisplayName
+		comment.CreatedByID = item.CreatedBy.ID
+	}
+	if item.ModifiedBy != nil {
+		comment.ModifiedBy = item.ModifiedBy.DisplayName
+	}
+	return comment
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// GetItemTimings returns when the backlog items under areaPath that were completed
+// since the given day were created, started and completed, from their state history
+func (c *Client) GetItemTimings(areaPath string, since time.Time, statesByType map[string][]models.WorkItemStateInfo) ([]models.ItemTiming, error) {
+	return c.GetItemTimingsContext(context.Background(), areaPath, since, statesByType)
+}
+
+// GetItemTimingsContext is like GetItemTimings but honors ctx cancellation
+func (c *Client) GetItemTimingsContext(ctx context.Context, areaPath string, since time.Time, statesByType map[string][]models.WorkItemStateInfo) ([]models.ItemTiming, error) {
+	items, err := c.QueryCompletedItemsContext(ctx, areaPath, since, models.AllStatesInCategory(statesByType, "Completed"))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	timelines, err := c.GetFieldTimelinesContext(ctx, ids, []string{"System.State"})
+	if err != nil {
+		return nil, err
+	}
+
+	return models.ItemTimings(items, timelines, statesByType, since), nil
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// APIError is a non-2xx response from Azure DevOps with its decoded error envelope
+type APIError struct {
+	StatusCode int
+	ID         string // $id of the envelope
+	Message    string // Human-readable message from the server
+	TypeKey    string // e.g. "RuleValidationException"
+	ErrorCode  int
+	Header     http.Header
+	Body       []byte // Raw response body
+}
+
+// apiErrorEnvelope is the JSON error body returned by Azure DevOps
+type apiErrorEnvelope struct {
+	ID               string `json:"$id"`
+	Message          string `json:"message"`
+	TypeKey          string `json:"typeKey"`
+	ErrorCode        int    `json:"errorCode"`
+	CustomProperties struct {
+		FieldReferenceName string `json:"FieldReferenceName"`
+	} `json:"customProperties"`
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("%s (HTTP %d)", e.Message, e.StatusCode)
+	}
+
+	body := strings.TrimSpace(string(e.Body))
+	// Auth failures often come back as an HTML sign-in page
+	if body == "" || strings.HasPrefix(body, "<") {
+		return fmt.Sprintf("API error %d: %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	if len(body) > 200 {
+		body = body[:200] + "..."
+	}
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, body)
+}
+
+// UnauthorizedError means the token is missing, expired or revoked (HTTP 401)
+type UnauthorizedError struct{ *APIError }
+
+func (e *UnauthorizedError) Unwrap() error { return e.APIError }
+
+// ForbiddenError means the token lacks the required scope or permission (HTTP 403)
+type ForbiddenError struct{ *APIError }
+
+func (e *ForbiddenError) Unwrap() error { return e.APIError }
+
+// NotFoundError means the work item, project or team does not exist (HTTP 404)
+type NotFoundError struct{ *APIError }
+
+func (e *NotFoundError) Unwrap() error { return e.APIError }
+
+// RuleValidationError means a field value was rejected by the process rules
+type RuleValidationError struct {
+	*APIError
+	Field string // Reference name of the offending field, if reported
+}
+
+func (e *RuleValidationError) Unwrap() error { return e.APIError }
+
+// RevisionConflictError means the work item changed since it was loaded
+type RevisionConflictError struct{ *APIError }
+
+func (e *RevisionConflictError) Unwrap() error { return e.APIError }
+
+// ThrottledError means the request was rate limited (HTTP 429)
+type ThrottledError struct {
+	*APIError
+	RetryAfter time.Duration // Zero if the server did not say
+}
+
+func (e *ThrottledError) Unwrap() error { return e.APIError }
+
+// newAPIError decodes an error response into the most specific error type
+func newAPIError(statusCode int, header http.Header, body []byte) error {
+	base := &APIError{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       body,
+	}
+
+	var envelope apiErrorEnvelope
+	if json.Unmarshal(body, &envelope) == nil {
+		base.ID = envelope.ID
+		base.Message = envelope.Message
+		base.TypeKey = envelope.TypeKey
+		base.ErrorCode = envelope.ErrorCode
+	}
+
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return &UnauthorizedError{base}
+	case statusCode == http.StatusForbidden:
+		return &ForbiddenError{base}
+	case statusCode == http.StatusNotFound:
+		return &NotFoundError{base}
+	case statusCode == http.StatusTooManyRequests:
+		retryAfter, _ := parseRetryAfter(header.Get("Retry-After"))
+		return &ThrottledError{APIError: base, RetryAfter: retryAfter}
+	case statusCode == http.StatusConflict || statusCode == http.StatusPreconditionFailed,
+		strings.Contains(base.TypeKey, "RevisionMismatch"),
+		strings.Contains(base.TypeKey, "TestOperation"): // failed "test /rev" patch operation
+		return &RevisionConflictError{base}
+	case base.TypeKey == "RuleValidationException":
+		return &RuleValidationError{APIError: base, Field: envelope.CustomProperties.FieldReferenceName}
+	}
+
+	return base
+}
+
+// IsOffline reports whether err means Azure DevOps couldn't be reached at all: its
+// name didn't resolve or no connection could be made. A slow server that times out
+// is not offline.
+func IsOffline(err error) bool {
+	var apiErr *APIError
+	if err == nil || errors.As(err, &apiErr) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// fieldsResponse represents the API response for project fields
+type fieldsResponse struct {
+	Count int            `json:"count"`
+	Value []fieldAPIItem `json:"value"`
+}
+
+type fieldAPIItem struct {
+	Name          string `json:"name"`
+	ReferenceName string `json:"referenceName"`
+	Type          string `json:"type"`
+	ReadOnly      bool   `json:"readOnly"`
+}
+
+// typeFieldsResponse represents the API response for the fields of a work item type
+type typeFieldsResponse struct {
+	Count int                `json:"count"`
+	Value []typeFieldAPIItem `json:"value"`
+}
+
+type typeFieldAPIItem struct {
+	Name           string        `json:"name"`
+	ReferenceName  string        `json:"referenceName"`
+	AlwaysRequired bool          `json:"alwaysRequired"`
+	AllowedValues  []interface{} `json:"allowedValues"`
+}
+
+// GetWorkItemTypeFields fetches the field definitions (type, required, allowed values)
+// for a work item type
+func (c *Client) GetWorkItemTypeFields(workItemType string) ([]models.FieldDefinition, error) {
+	return c.GetWorkItemTypeFieldsContext(context.Background(), workItemType)
+}
+
+// GetWorkItemTypeFieldsContext is like GetWorkItemTypeFields but honors ctx cancellation
+func (c *Client) GetWorkItemTypeFieldsContext(ctx context.Context, workItemType string) ([]models.FieldDefinition, error) {
+	endpoint := fmt.Sprintf("/wit/workitemtypes/%s/fields?$expand=allowedValues", url.PathEscape(workItemType))
+	resp, err := c.get(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var typeResp typeFieldsResponse
+	if err := decode(resp, &typeResp); err != nil {
+		return nil, err
+	}
+
+	// The type endpoint doesn't report data types, so look them up project-wide
+	fieldTypes := make(map[string]models.FieldType)
+	if resp, err := c.get(ctx, "/wit/fields"); err == nil {
+		var fieldsResp fieldsResponse
+		if decode(resp, &fieldsResp) == nil {
+			for _, f := range fieldsResp.Value {
+				fieldTypes[f.ReferenceName] = models.FieldType(f.Type)
+			}
+		}
+	}
+
+	defs := make([]models.FieldDefinition, 0, len(typeResp.Value))
+	for _, f := range typeResp.Value {
+		fieldType, ok := fieldTypes[f.ReferenceName]
+		if !ok {
+			fieldType = models.KnownFieldType(f.ReferenceName)
+		}
+
+		def := models.FieldDefinition{
+			ReferenceName: f.ReferenceName,
+			Name:          f.Name,
+			Type:          fieldType,
+			Required:      f.AlwaysRequired,
+		}
+		for _, v := range f.AllowedValues {
+			def.AllowedValues = append(def.AllowedValues, fmt.Sprint(v))
+		}
+		defs = append(defs, def)
+	}
+
+	return defs, nil
+}
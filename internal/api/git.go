@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// artifactLinkRel is the relation type of links to pull requests, commits, builds etc.
+const artifactLinkRel = "ArtifactLink"
+
+type gitRepository struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	WebURL string `json:"webUrl"`
+}
+
+type gitPullRequest struct {
+	PullRequestID int    `json:"pullRequestId"`
+	Title         string `json:"title"`
+	Status        string `json:"status"`
+	MergeStatus   string `json:"mergeStatus"`
+	IsDraft       bool   `json:"isDraft"`
+	CreatedBy     struct {
+		DisplayName string `json:"displayName"`
+	} `json:"createdBy"`
+	CreationDate time.Time `json:"creationDate"`
+	Reviewers    []struct {
+		DisplayName string `json:"displayName"`
+		Vote        int    `json:"vote"`
+	} `json:"reviewers"`
+}
+
+type gitCommit struct {
+	CommitID string `json:"commitId"`
+	Comment  string `json:"comment"`
+	Author   struct {
+		Name string    `json:"name"`
+		Date time.Time `json:"date"`
+	} `json:"author"`
+}
+
+// parseArtifactLink decodes a Git artifact URI such as
+// vstfs:///Git/PullRequestId/{project}%2F{repository}%2F{id}. Returns nil for
+// artifacts that aren't pull requests, commits or branches.
+func parseArtifactLink(rel workItemRelation) *models.DevelopmentLink {
+	if rel.Rel != artifactLinkRel {
+		return nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(rel.URL, "vstfs:///"), "/", 3)
+	if len(parts) != 3 || parts[0] != "Git" {
+		return nil
+	}
+	id, err := url.PathUnescape(parts[2])
+	if err != nil {
+		return nil
+	}
+	// The branch name in refs may itself contain slashes
+	ids := strings.SplitN(id, "/", 3)
+	if len(ids) != 3 {
+		return nil
+	}
+
+	link := &models.DevelopmentLink{
+		ProjectID:    ids[0],
+		RepositoryID: ids[1],
+		Ref:          ids[2],
+	}
+	switch parts[1] {
+	case "PullRequestId":
+		link.Kind = models.DevelopmentPullRequest
+	case "Commit":
+		link.Kind = models.DevelopmentCommit
+	case "Ref":
+		// "GB" prefixes branches; tags ("GT") are not shown
+		if !strings.HasPrefix(link.Ref, "GB") {
+			return nil
+		}
+		link.Kind = models.DevelopmentBranch
+		link.Ref = strings.TrimPrefix(link.Ref, "GB")
+	default:
+		return nil
+	}
+	return link
+}
+
+// devLinkConcurrency is the number of development link requests made at once
+const devLinkConcurrency = 6
+
+// populateDevelopmentLinks fetches the details of linked pull requests and commits.
+// Links whose details can't be fetched keep only what the artifact URI contains.
+func (c *Client) populateDevelopmentLinks(ctx context.Context, item *models.WorkItem) {
+	if len(item.DevelopmentLinks) == 0 {
+		return
+	}
+
+	// Fetch a few details at a time; each link and repository takes its own request
+	sem := make(chan struct{}, devLinkConcurrency)
+	var wg sync.WaitGroup
+	fetch := func(fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			fn()
+		}()
+	}
+
+	repos := make(map[string]*gitRepository)
+	for i := range item.DevelopmentLinks {
+		link := &item.DevelopmentLinks[i]
+		repoBase := fmt.Sprintf("%s/%s/_apis/git/repositories/%s", c.collectionURL, link.ProjectID, link.RepositoryID)
+
+		if _, ok := repos[link.RepositoryID]; !ok {
+			repo := &gitRepository{}
+			repos[link.RepositoryID] = repo
+			fetch(func() {
+				if resp, err := c.getWithBase(ctx, repoBase, ""); err == nil {
+					if decode(resp, repo) != nil {
+						*repo = gitRepository{}
+					}
+				}
+			})
+		}
+
+		switch link.Kind {
+		case models.DevelopmentPullRequest:
+			fetch(func() { c.fillPullRequest(ctx, repoBase, link) })
+		case models.DevelopmentCommit:
+			fetch(func() { c.fillCommit(ctx, repoBase, link) })
+		}
+	}
+	wg.Wait()
+
+	for i := range item.DevelopmentLinks {
+		link := &item.DevelopmentLinks[i]
+		repo := repos[link.RepositoryID]
+		link.Repository = repo.Name
+		if repo.WebURL == "" {
+			continue
+		}
+		switch link.Kind {
+		case models.DevelopmentPullRequest:
+			link.WebURL = fmt.Sprintf("%s/pullrequest/%s", repo.WebURL, link.Ref)
+		case models.DevelopmentCommit:
+			link.WebURL = fmt.Sprintf("%s/commit/%s", repo.WebURL, link.Ref)
+		case models.DevelopmentBranch:
+			link.WebURL = fmt.Sprintf("%s?version=GB%s", repo.WebURL, url.QueryEscape(link.Ref))
+		}
+	}
+}
+
+// fillPullRequest fetches a pull request's title, status and reviewers
+func (c *Client) fillPullRequest(ctx context.Context, repoBase string, link *models.DevelopmentLink) {
+	resp, err := c.getWithBase(ctx, repoBase, "/pullRequests/"+link.Ref)
+	if err != nil {
+		return
+	}
+	var pr gitPullRequest
+	if decode(resp, &pr) != nil {
+		return
+	}
+
+	link.Title = pr.Title
+	link.Status = pr.Status
+	link.MergeStatus = pr.MergeStatus
+	link.IsDraft = pr.IsDraft
+	link.Author = pr.CreatedBy.DisplayName
+	link.Date = pr.CreationDate
+	for _, r := range pr.Reviewers {
+		link.Reviewers = append(link.Reviewers, models.Reviewer{Name: r.DisplayName, Vote: r.Vote})
+	}
+}
+
+// fillCommit fetches a commit's message and author
+func (c *Client) fillCommit(ctx context.Context, repoBase string, link *models.DevelopmentLink) {
+	resp, err := c.getWithBase(ctx, repoBase, "/commits/"+link.Ref)
+	if err != nil {
+		return
+	}
+	var commit gitCommit
+	if decode(resp, &commit) != nil {
+		return
+	}
+
+	link.Title = strings.TrimSpace(strings.SplitN(commit.Comment, "\n", 2)[0])
+	link.Author = commit.Author.Name
+	link.Date = commit.Author.Date
+}
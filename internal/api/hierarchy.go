@@ -0,0 +1,80 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// wiqlLinksResponse represents the response from a WIQL query on work item links
+type wiqlLinksResponse struct {
+	WorkItemRelations []struct {
+		Rel    string `json:"rel"`
+		Source *struct {
+			ID int `json:"id"`
+		} `json:"source"`
+		Target struct {
+			ID int `json:"id"`
+		} `json:"target"`
+	} `json:"workItemRelations"`
+}
+
+// QueryWorkItemHierarchy queries the work items matching the list filters together
+// with all their descendants, and the parent-child links between them in tree order
+func (c *Client) QueryWorkItemHierarchy(sprintPath, state, assigned, areaPath string) ([]models.WorkItem, []models.HierarchyLink, error) {
+	return c.QueryWorkItemHierarchyContext(context.Background(), sprintPath, state, assigned, areaPath)
+}
+
+// QueryWorkItemHierarchyContext is like QueryWorkItemHierarchy but honors ctx cancellation
+func (c *Client) QueryWorkItemHierarchyContext(ctx context.Context, sprintPath, state, assigned, areaPath string) ([]models.WorkItem, []models.HierarchyLink, error) {
+	// The filters select the top-level items; a recursive query follows their children
+	query := `SELECT [System.Id]
+FROM WorkItemLinks
+WHERE ([Source].[System.TeamProject] = @project`
+	query += filterClauses("[Source].", sprintPath, state, assigned, areaPath)
+	query += `)
+  AND ([System.Links.LinkType] = 'System.LinkTypes.Hierarchy-Forward')
+  AND ([Target].[System.TeamProject] = @project)
+ORDER BY [System.Id]
+MODE (Recursive)`
+
+	bodyBytes, err := json.Marshal(wiqlRequest{Query: query})
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshaling WIQL request: %w", err)
+	}
+
+	resp, err := c.postQuery(ctx, "/wit/wiql", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var wiqlResp wiqlLinksResponse
+	if err := decode(resp, &wiqlResp); err != nil {
+		return nil, nil, err
+	}
+
+	// Top-level items come without a source
+	var links []models.HierarchyLink
+	var ids []string
+	seen := make(map[int]bool)
+	for _, rel := range wiqlResp.WorkItemRelations {
+		link := models.HierarchyLink{ChildID: rel.Target.ID}
+		if rel.Source != nil {
+			link.ParentID = rel.Source.ID
+		}
+		links = append(links, link)
+		if !seen[link.ChildID] {
+			seen[link.ChildID] = true
+			ids = append(ids, fmt.Sprintf("%d", link.ChildID))
+		}
+	}
+
+	items, err := c.GetWorkItemsContext(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, links, nil
+}
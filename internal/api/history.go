@@ -0,0 +1,278 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// updatesPageSize is the maximum number of updates the API returns per request
+const updatesPageSize = 200
+
+// updatesResponse represents the response from the work item updates API
+type updatesResponse struct {
+	Count int              `json:"count"`
+	Value []workItemUpdate `json:"value"`
+}
+
+type workItemUpdate struct {
+	ID          int                    `json:"id"`
+	Rev         int                    `json:"rev"`
+	RevisedBy   identityRef            `json:"revisedBy"`
+	RevisedDate time.Time              `json:"revisedDate"`
+	Fields      map[string]fieldUpdate `json:"fields"`
+	Relations   *relationUpdates       `json:"relations"`
+}
+
+type fieldUpdate struct {
+	OldValue interface{} `json:"oldValue"`
+	NewValue interface{} `json:"newValue"`
+}
+
+type relationUpdates struct {
+	Added   []workItemRelation `json:"added"`
+	Removed []workItemRelation `json:"removed"`
+}
+
+// historyIgnoredFields change on every revision and carry no information
+var historyIgnoredFields = map[string]bool{
+	"System.Rev":            true,
+	"System.ChangedDate":    true,
+	"System.ChangedBy":      true,
+	"System.AuthorizedDate": true,
+	"System.AuthorizedAs":   true,
+	"System.RevisedDate":    true,
+	"System.Watermark":      true,
+	"System.PersonId":       true,
+	"System.Id":             true,
+	"System.CommentCount":   true,
+}
+
+// GetWorkItemHistory fetches the revisions of a work item, oldest first
+func (c *Client) GetWorkItemHistory(id int) ([]models.WorkItemRevision, error) {
+	return c.GetWorkItemHistoryContext(context.Background(), id)
+}
+
+// GetWorkItemHistoryContext is like GetWorkItemHistory but honors ctx cancellation
+func (c *Client) GetWorkItemHistoryContext(ctx context.Context, id int) ([]models.WorkItemRevision, error) {
+	updates, err := c.getUpdates(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []models.WorkItemRevision
+	for _, u := range updates {
+		rev := convertUpdate(u)
+		// Updates that only touched bookkeeping fields aren't worth listing
+		if rev.ChangeCount() > 0 {
+			revisions = append(revisions, rev)
+		}
+	}
+	return revisions, nil
+}
+
+// getUpdates fetches all updates of a work item, oldest first
+func (c *Client) getUpdates(ctx context.Context, id int) ([]workItemUpdate, error) {
+	var updates []workItemUpdate
+	for skip := 0; ; skip += updatesPageSize {
+		endpoint := fmt.Sprintf("/wit/workItems/%d/updates?$top=%d&$skip=%d", id, updatesPageSize, skip)
+		resp, err := c.get(ctx, endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("fetching history of #%d: %w", id, err)
+		}
+
+		var page updatesResponse
+		if err := decode(resp, &page); err != nil {
+			return nil, err
+		}
+		updates = append(updates, page.Value...)
+
+		if len(page.Value) < updatesPageSize {
+			return updates, nil
+		}
+	}
+}
+
+// convertUpdate converts an API update to a revision
+func convertUpdate(u workItemUpdate) models.WorkItemRevision {
+	rev := models.WorkItemRevision{
+		Rev:         u.Rev,
+		RevisedBy:   u.RevisedBy.DisplayName,
+		RevisedDate: updateDate(u),
+	}
+
+	names := make([]string, 0, len(u.Fields))
+	for name := range u.Fields {
+		if !historyIgnoredFields[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := u.Fields[name]
+		longText := name == "System.History" || models.KnownFieldType(name) == models.FieldTypeHTML
+		delta := models.FieldDelta{
+			Field:    name,
+			OldValue: historyValue(f.OldValue, longText),
+			NewValue: historyValue(f.NewValue, longText),
+			LongText: longText,
+		}
+		if delta.OldValue == delta.NewValue {
+			continue
+		}
+		rev.Fields = append(rev.Fields, delta)
+	}
+
+	if u.Relations != nil {
+		for _, r := range u.Relations.Added {
+			rev.Links = append(rev.Links, convertLinkDelta(r, true))
+		}
+		for _, r := range u.Relations.Removed {
+			rev.Links = append(rev.Links, convertLinkDelta(r, false))
+		}
+	}
+
+	return rev
+}
+
+// updateDate returns when an update was made. The revised date of the latest
+// update is a far-future placeholder; the changed date is when it was actually made.
+func updateDate(u workItemUpdate) time.Time {
+	if changed, ok := u.Fields["System.ChangedDate"]; ok {
+		if s, ok := changed.NewValue.(string); ok {
+			if t, err := time.Parse(time.RFC3339, s); err == nil {
+				return t
+			}
+		}
+	}
+	return u.RevisedDate
+}
+
+// historyValue formats a field value from the updates API for display
+func historyValue(v interface{}, longText bool) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if longText {
+			return stripHTML(val)
+		}
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case map[string]interface{}:
+		// Identity fields such as System.AssignedTo
+		if name, ok := val["displayName"].(string); ok {
+			return name
+		}
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// convertLinkDelta converts an added or removed relation to a link change
+func convertLinkDelta(r workItemRelation, added bool) models.LinkDelta {
+	delta := models.LinkDelta{Added: added}
+	name, _ := r.Attributes["name"].(string)
+
+	switch {
+	case r.Rel == "AttachedFile":
+		delta.LinkType = "Attachment"
+		delta.Description = name
+	case r.Rel == "Hyperlink":
+		delta.LinkType = "Hyperlink"
+		delta.Description = r.URL
+	case strings.HasPrefix(r.Rel, "ArtifactLink"):
+		delta.LinkType = "Artifact"
+		delta.Description = name
+		if delta.Description == "" {
+			delta.Description = r.URL
+		}
+	default:
+		delta.LinkType = getLinkTypeName(r.Rel)
+		delta.Description = fmt.Sprintf("#%d", extractWorkItemID(r.URL))
+	}
+	return delta
+}
+
+// timelineConcurrency is the number of work item histories fetched at once
+const timelineConcurrency = 8
+
+// GetFieldTimelines fetches how the given fields changed over the lifetime of each
+// work item, keyed by work item ID
+func (c *Client) GetFieldTimelines(ids []int, fields []string) (map[int]models.FieldTimeline, error) {
+	return c.GetFieldTimelinesContext(context.Background(), ids, fields)
+}
+
+// GetFieldTimelinesContext is like GetFieldTimelines but honors ctx cancellation
+func (c *Client) GetFieldTimelinesContext(ctx context.Context, ids []int, fields []string) (map[int]models.FieldTimeline, error) {
+	wanted := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		wanted[f] = true
+	}
+
+	type result struct {
+		id       int
+		timeline models.FieldTimeline
+		err      error
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Fetch a few histories at a time; each work item takes its own requests
+	sem := make(chan struct{}, timelineConcurrency)
+	results := make(chan result, len(ids))
+	for _, id := range ids {
+		go func(id int) {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			updates, err := c.getUpdates(ctx, id)
+			if err != nil {
+				results <- result{id: id, err: err}
+				return
+			}
+			results <- result{id: id, timeline: fieldTimeline(updates, wanted)}
+		}(id)
+	}
+
+	timelines := make(map[int]models.FieldTimeline, len(ids))
+	for range ids {
+		r := <-results
+		if r.err != nil {
+			return nil, r.err
+		}
+		timelines[r.id] = r.timeline
+	}
+	return timelines, nil
+}
+
+// fieldTimeline extracts the changes of the wanted fields from a work item's updates
+func fieldTimeline(updates []workItemUpdate, wanted map[string]bool) models.FieldTimeline {
+	var timeline models.FieldTimeline
+	for _, u := range updates {
+		date := updateDate(u)
+		names := make([]string, 0, len(u.Fields))
+		for name := range u.Fields {
+			if wanted[name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			timeline = append(timeline, models.FieldEvent{
+				Date:  date,
+				Field: name,
+				Value: historyValue(u.Fields[name].NewValue, false),
+			})
+		}
+	}
+	return timeline
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"time"
 
 	"github.com/samuelenocsson/devops-tui/internal/models"
@@ -28,7 +29,12 @@ type iterationAttrs struct {
 
 // GetIterations fetches all iterations (sprints) for the team
 func (c *Client) GetIterations() ([]models.Iteration, error) {
-	resp, err := c.getTeam("/work/teamsettings/iterations")
+	return c.GetIterationsContext(context.Background())
+}
+
+// GetIterationsContext is like GetIterations but honors ctx cancellation
+func (c *Client) GetIterationsContext(ctx context.Context) ([]models.Iteration, error) {
+	resp, err := c.getTeam(ctx, "/work/teamsettings/iterations")
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +69,12 @@ func (c *Client) GetIterations() ([]models.Iteration, error) {
 
 // GetCurrentIteration returns the current iteration
 func (c *Client) GetCurrentIteration() (*models.Iteration, error) {
-	iterations, err := c.GetIterations()
+	return c.GetCurrentIterationContext(context.Background())
+}
+
+// GetCurrentIterationContext is like GetCurrentIteration but honors ctx cancellation
+func (c *Client) GetCurrentIterationContext(ctx context.Context) (*models.Iteration, error) {
+	iterations, err := c.GetIterationsContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// linkTypeRef converts a link type name as returned in RelatedLink.LinkType back to
+// its relation reference name
+func linkTypeRef(name string) string {
+	switch name {
+	case "Child":
+		return "System.LinkTypes.Hierarchy-Forward"
+	case "Parent":
+		return "System.LinkTypes.Hierarchy-Reverse"
+	case "Related":
+		return "System.LinkTypes.Related"
+	case "Successor":
+		return "System.LinkTypes.Dependency-Forward"
+	case "Predecessor":
+		return "System.LinkTypes.Dependency-Reverse"
+	case "Affects":
+		return "Microsoft.VSTS.Common.Affects-Forward"
+	case "Affected By":
+		return "Microsoft.VSTS.Common.Affects-Reverse"
+	case "Duplicate":
+		return "System.LinkTypes.Duplicate-Forward"
+	case "Duplicate Of":
+		return "System.LinkTypes.Duplicate-Reverse"
+	default:
+		// Unknown types are reported by their reference name
+		return name
+	}
+}
+
+// AddLink links a work item to another one. linkType is a RelatedLink.LinkType name,
+// e.g. "Parent", "Child", "Related", "Predecessor" or "Successor". Adding a parent
+// replaces the current one.
+func (c *Client) AddLink(id int, linkType string, targetID int) error {
+	return c.AddLinkContext(context.Background(), id, linkType, targetID)
+}
+
+// AddLinkContext is like AddLink but honors ctx cancellation
+func (c *Client) AddLinkContext(ctx context.Context, id int, linkType string, targetID int) error {
+	if targetID == id {
+		return fmt.Errorf("cannot link #%d to itself", id)
+	}
+
+	rev, relations, err := c.getRelations(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	rel := linkTypeRef(linkType)
+	var ops []map[string]interface{}
+	for i := len(relations) - 1; i >= 0; i-- {
+		r := relations[i]
+		if r.Rel == rel && extractWorkItemID(r.URL) == targetID {
+			return fmt.Errorf("#%d is already linked to #%d as %s", id, targetID, linkType)
+		}
+		// A work item has at most one parent
+		if linkType == "Parent" && r.Rel == rel {
+			ops = append(ops, map[string]interface{}{
+				"op":   "remove",
+				"path": fmt.Sprintf("/relations/%d", i),
+			})
+		}
+	}
+
+	ops = append(ops, map[string]interface{}{
+		"op":   "add",
+		"path": "/relations/-",
+		"value": map[string]interface{}{
+			"rel": rel,
+			"url": c.workItemAPIURL(targetID),
+		},
+	})
+
+	return c.patchRelations(ctx, id, rev, ops)
+}
+
+// RemoveLink removes the link of the given type between a work item and another one
+func (c *Client) RemoveLink(id int, linkType string, targetID int) error {
+	return c.RemoveLinkContext(context.Background(), id, linkType, targetID)
+}
+
+// RemoveLinkContext is like RemoveLink but honors ctx cancellation
+func (c *Client) RemoveLinkContext(ctx context.Context, id int, linkType string, targetID int) error {
+	rev, relations, err := c.getRelations(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	rel := linkTypeRef(linkType)
+	for i, r := range relations {
+		if r.Rel == rel && extractWorkItemID(r.URL) == targetID {
+			ops := []map[string]interface{}{{
+				"op":   "remove",
+				"path": fmt.Sprintf("/relations/%d", i),
+			}}
+			return c.patchRelations(ctx, id, rev, ops)
+		}
+	}
+
+	return fmt.Errorf("#%d has no %s link to #%d", id, linkType, targetID)
+}
+
+// getRelations fetches a work item's current revision and relations. Relations are
+// removed by index, so indexes must come from the revision being patched.
+func (c *Client) getRelations(ctx context.Context, id int) (int, []workItemRelation, error) {
+	endpoint := fmt.Sprintf("/wit/workitems/%d?$expand=relations", id)
+	resp, err := c.get(ctx, endpoint)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var item workItemAPIItem
+	if err := decode(resp, &item); err != nil {
+		return 0, nil, err
+	}
+	return item.Rev, item.Relations, nil
+}
+
+// patchRelations applies relation operations against the given revision
+func (c *Client) patchRelations(ctx context.Context, id, rev int, ops []map[string]interface{}) error {
+	patchDoc := append([]map[string]interface{}{{
+		"op":    "test",
+		"path":  "/rev",
+		"value": rev,
+	}}, ops...)
+
+	bodyBytes, err := json.Marshal(patchDoc)
+	if err != nil {
+		return fmt.Errorf("marshaling patch document: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/wit/workitems/%d", id)
+	resp, err := c.patch(ctx, endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
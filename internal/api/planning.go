@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// QueryPlanningItems returns the backlog items (requirements and bugs) in the given
+// iteration paths, limited to areaPath and below if set
+func (c *Client) QueryPlanningItems(iterationPaths []string, areaPath string) ([]models.WorkItem, error) {
+	return c.QueryPlanningItemsContext(context.Background(), iterationPaths, areaPath)
+}
+
+// QueryPlanningItemsContext is like QueryPlanningItems but honors ctx cancellation
+func (c *Client) QueryPlanningItemsContext(ctx context.Context, iterationPaths []string, areaPath string) ([]models.WorkItem, error) {
+	if len(iterationPaths) == 0 {
+		return []models.WorkItem{}, nil
+	}
+
+	quoted := make([]string, len(iterationPaths))
+	for i, path := range iterationPaths {
+		quoted[i] = "'" + escapeWIQL(path) + "'"
+	}
+
+	return c.queryBacklogItems(ctx, fmt.Sprintf(`
+  AND [System.IterationPath] IN (%s)`, strings.Join(quoted, ", ")), areaPath)
+}
+
+// QueryFlowItems returns the backlog items under areaPath that changed since the
+// given day or aren't in one of doneStates. Items finished before then are left out.
+func (c *Client) QueryFlowItems(areaPath string, since time.Time, doneStates []string) ([]models.WorkItem, error) {
+	return c.QueryFlowItemsContext(context.Background(), areaPath, since, doneStates)
+}
+
+// QueryFlowItemsContext is like QueryFlowItems but honors ctx cancellation
+func (c *Client) QueryFlowItemsContext(ctx context.Context, areaPath string, since time.Time, doneStates []string) ([]models.WorkItem, error) {
+	condition := fmt.Sprintf(`
+  AND [System.ChangedDate] >= '%s'`, since.Format("2006-01-02"))
+	if len(doneStates) > 0 {
+		quoted := make([]string, len(doneStates))
+		for i, state := range doneStates {
+			quoted[i] = "'" + escapeWIQL(state) + "'"
+		}
+		condition = fmt.Sprintf(`
+  AND ([System.ChangedDate] >= '%s' OR [System.State] NOT IN (%s))`, since.Format("2006-01-02"), strings.Join(quoted, ", "))
+	}
+	return c.queryBacklogItems(ctx, condition, areaPath)
+}
+
+// QueryCompletedItems returns the backlog items under areaPath that are in one of
+// doneStates and changed since the given day, i.e. that may have been completed since
+func (c *Client) QueryCompletedItems(areaPath string, since time.Time, doneStates []string) ([]models.WorkItem, error) {
+	return c.QueryCompletedItemsContext(context.Background(), areaPath, since, doneStates)
+}
+
+// QueryCompletedItemsContext is like QueryCompletedItems but honors ctx cancellation
+func (c *Client) QueryCompletedItemsContext(ctx context.Context, areaPath string, since time.Time, doneStates []string) ([]models.WorkItem, error) {
+	condition := fmt.Sprintf(`
+  AND [System.ChangedDate] >= '%s'`, since.Format("2006-01-02"))
+	if len(doneStates) > 0 {
+		quoted := make([]string, len(doneStates))
+		for i, state := range doneStates {
+			quoted[i] = "'" + escapeWIQL(state) + "'"
+		}
+		condition += fmt.Sprintf(`
+  AND [System.State] IN (%s)`, strings.Join(quoted, ", "))
+	}
+	return c.queryBacklogItems(ctx, condition, areaPath)
+}
+
+// queryBacklogItems returns the requirements and bugs matching the extra WIQL
+// condition, limited to areaPath and below if set, in backlog order
+func (c *Client) queryBacklogItems(ctx context.Context, condition, areaPath string) ([]models.WorkItem, error) {
+	query := `SELECT [System.Id]
+FROM WorkItems
+WHERE [System.TeamProject] = @project` + condition + `
+  AND ([System.WorkItemType] IN GROUP 'Microsoft.RequirementCategory'
+    OR [System.WorkItemType] IN GROUP 'Microsoft.BugCategory')
+  AND [System.State] <> 'Removed'`
+
+	if areaPath != "" && areaPath != "all" {
+		areaPath = strings.Trim(areaPath, "\\")
+		query += fmt.Sprintf(`
+  AND [System.AreaPath] UNDER '%s'`, escapeWIQL(areaPath))
+	}
+
+	query += `
+ORDER BY [Microsoft.VSTS.Common.BacklogPriority] ASC, [System.Id] ASC`
+
+	bodyBytes, err := json.Marshal(wiqlRequest{Query: query})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling WIQL request: %w", err)
+	}
+
+	resp, err := c.postQuery(ctx, "/wit/wiql", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	var wiqlResp wiqlResponse
+	if err := decode(resp, &wiqlResp); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(wiqlResp.WorkItems))
+	for _, wi := range wiqlResp.WorkItems {
+		ids = append(ids, fmt.Sprintf("%d", wi.ID))
+	}
+
+	items, err := c.GetWorkItemsContext(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// GetWorkItems doesn't keep the order of the query
+	order := make(map[int]int, len(wiqlResp.WorkItems))
+	for i, wi := range wiqlResp.WorkItems {
+		order[wi.ID] = i
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return order[items[i].ID] < order[items[j].ID]
+	})
+	return items, nil
+}
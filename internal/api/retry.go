@@ -0,0 +1,159 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	maxRetries      = 4
+	retryBaseDelay  = 500 * time.Millisecond
+	retryMaxDelay   = 30 * time.Second
+	retryAfterLimit = 60 * time.Second
+)
+
+// RateLimit describes the remaining Azure DevOps rate-limit budget as reported by the
+// X-RateLimit-* response headers
+type RateLimit struct {
+	Known     bool          // True once the server has reported rate-limit headers
+	Resource  string        // Throttled resource, e.g. "Core"
+	Limit     int           // Total TSTUs allowed in the window
+	Remaining int           // TSTUs left in the window
+	Reset     time.Time     // When the window resets
+	Delay     time.Duration // Delay the server applied to the last request
+}
+
+// IsLow returns true if less than a fifth of the budget is left or the server is delaying requests
+func (r RateLimit) IsLow() bool {
+	if !r.Known {
+		return false
+	}
+	if r.Delay > 0 {
+		return true
+	}
+	return r.Limit > 0 && r.Remaining*5 < r.Limit
+}
+
+// rateLimitTracker records the latest rate-limit headers seen by the client
+type rateLimitTracker struct {
+	mu      sync.Mutex
+	current RateLimit
+}
+
+// update records the rate-limit headers of a response, if any
+func (t *rateLimitTracker) update(h http.Header) {
+	if h.Get("X-RateLimit-Limit") == "" && h.Get("X-RateLimit-Remaining") == "" && h.Get("X-RateLimit-Delay") == "" {
+		return
+	}
+
+	rl := RateLimit{
+		Known:    true,
+		Resource: h.Get("X-RateLimit-Resource"),
+	}
+	rl.Limit, _ = strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	rl.Remaining, _ = strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+	if delay, err := strconv.ParseFloat(h.Get("X-RateLimit-Delay"), 64); err == nil {
+		rl.Delay = time.Duration(delay * float64(time.Second))
+	}
+
+	t.mu.Lock()
+	t.current = rl
+	t.mu.Unlock()
+}
+
+// get returns the latest recorded rate limit
+func (t *rateLimitTracker) get() RateLimit {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.current
+}
+
+// RateLimit returns the remaining rate-limit budget reported by the server
+func (c *Client) RateLimit() RateLimit {
+	return c.rateLimit.get()
+}
+
+// shouldRetry reports whether a failed request is worth retrying
+func shouldRetry(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	// Cancellation is deliberate, not transient
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	// Without a network the cache is shown instead, which shouldn't wait for retries
+	if IsOffline(err) {
+		return false
+	}
+	// Transport errors (connection reset, timeout) are transient
+	return err != nil
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryDelay returns how long to wait before the given retry attempt (0-based).
+// Retry-After wins over exponential backoff when the server sends it.
+func retryDelay(err error, attempt int) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if d, ok := parseRetryAfter(apiErr.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+
+	// Exponential backoff with full jitter
+	backoff := retryBaseDelay << attempt
+	if backoff > retryMaxDelay {
+		backoff = retryMaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(backoff)) + 1)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	var d time.Duration
+	if secs, err := strconv.Atoi(value); err == nil {
+		d = time.Duration(secs) * time.Second
+	} else if t, err := http.ParseTime(value); err == nil {
+		d = time.Until(t)
+	} else {
+		return 0, false
+	}
+
+	if d < 0 {
+		d = 0
+	}
+	if d > retryAfterLimit {
+		d = retryAfterLimit
+	}
+	return d, true
+}
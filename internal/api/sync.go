@@ -0,0 +1,114 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// syncMargin is subtracted from the last sync time so changes aren't missed when
+// the local clock is ahead of the server's
+const syncMargin = 5 * time.Minute
+
+// SyncWorkItems is like QueryWorkItems, but only fetches the work items that changed
+// since the given time or are missing from cached. The others are taken from cached,
+// with their parent titles looked up again.
+func (c *Client) SyncWorkItems(sprintPath, state, assigned, areaPath string, since time.Time, cached map[int]models.WorkItem) ([]models.WorkItem, error) {
+	return c.SyncWorkItemsContext(context.Background(), sprintPath, state, assigned, areaPath, since, cached)
+}
+
+// SyncWorkItemsContext is like SyncWorkItems but honors ctx cancellation
+func (c *Client) SyncWorkItemsContext(ctx context.Context, sprintPath, state, assigned, areaPath string, since time.Time, cached map[int]models.WorkItem) ([]models.WorkItem, error) {
+	query := `SELECT [System.Id]
+FROM WorkItems
+WHERE [System.TeamProject] = @project` + filterClauses("", sprintPath, state, assigned, areaPath)
+
+	ids, err := c.queryIDs(ctx, query+`
+ORDER BY [System.ChangedDate] DESC`, false)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := c.queryIDs(ctx, query+fmt.Sprintf(`
+  AND [System.ChangedDate] > '%s'`, since.Add(-syncMargin).UTC().Format(time.RFC3339)), true)
+	if err != nil {
+		return nil, err
+	}
+	stale := make(map[int]bool, len(changed))
+	for _, id := range changed {
+		stale[id] = true
+	}
+
+	var fetch []string
+	for _, id := range ids {
+		if _, ok := cached[id]; !ok || stale[id] {
+			fetch = append(fetch, strconv.Itoa(id))
+		}
+	}
+	fetched, err := c.GetWorkItemsContext(ctx, fetch)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]models.WorkItem, len(ids))
+	for _, item := range fetched {
+		byID[item.ID] = item
+	}
+
+	// A parent can be renamed without its children changing, so the parent titles of
+	// cached items are looked up again
+	var reused []models.WorkItem
+	for _, id := range ids {
+		if _, ok := byID[id]; ok {
+			continue
+		}
+		if item, ok := cached[id]; ok && !stale[id] {
+			reused = append(reused, item)
+		}
+	}
+	c.populateParentTitles(ctx, reused)
+	for _, item := range reused {
+		byID[item.ID] = item
+	}
+
+	items := make([]models.WorkItem, 0, len(ids))
+	for _, id := range ids {
+		if item, ok := byID[id]; ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// queryIDs runs a flat WIQL query and returns the IDs of the work items it matched.
+// With timePrecision, date conditions compare times rather than whole days.
+func (c *Client) queryIDs(ctx context.Context, query string, timePrecision bool) ([]int, error) {
+	bodyBytes, err := json.Marshal(wiqlRequest{Query: query})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling WIQL request: %w", err)
+	}
+
+	endpoint := "/wit/wiql"
+	if timePrecision {
+		endpoint += "?timePrecision=true"
+	}
+	resp, err := c.postQuery(ctx, endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	var wiqlResp wiqlResponse
+	if err := decode(resp, &wiqlResp); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(wiqlResp.WorkItems))
+	for i, wi := range wiqlResp.WorkItems {
+		ids[i] = wi.ID
+	}
+	return ids, nil
+}
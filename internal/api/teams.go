@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/samuelenocsson/devops-tui/internal/models"
 )
@@ -24,11 +26,15 @@ type identityRef struct {
 
 // GetTeamMembers fetches all members of the configured team
 func (c *Client) GetTeamMembers() ([]models.TeamMember, error) {
-	// Azure DevOps API: GET https://dev.azure.com/{org}/_apis/projects/{project}/teams/{team}/members
-	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s/teams/%s/members?api-version=%s",
-		c.organization, c.project, c.team, apiVersion)
+	return c.GetTeamMembersContext(context.Background())
+}
+
+// GetTeamMembersContext is like GetTeamMembers but honors ctx cancellation
+func (c *Client) GetTeamMembersContext(ctx context.Context) ([]models.TeamMember, error) {
+	// Azure DevOps API: GET {collection}/_apis/projects/{project}/teams/{team}/members
+	endpoint := fmt.Sprintf("/_apis/projects/%s/teams/%s/members", url.PathEscape(c.project), url.PathEscape(c.team))
 
-	resp, err := c.doRequest("GET", url, nil)
+	resp, err := c.getWithBaseAndVersion(ctx, c.collectionURL, endpoint, apiVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -49,3 +55,41 @@ func (c *Client) GetTeamMembers() ([]models.TeamMember, error) {
 
 	return members, nil
 }
+
+// connectionDataResponse represents the response from the connection data API
+type connectionDataResponse struct {
+	AuthenticatedUser struct {
+		ID                  string `json:"id"`
+		ProviderDisplayName string `json:"providerDisplayName"`
+		Properties          struct {
+			Account struct {
+				Value string `json:"$value"`
+			} `json:"Account"`
+		} `json:"properties"`
+	} `json:"authenticatedUser"`
+}
+
+// GetCurrentUser fetches the identity the client is authenticated as
+func (c *Client) GetCurrentUser() (*models.TeamMember, error) {
+	return c.GetCurrentUserContext(context.Background())
+}
+
+// GetCurrentUserContext is like GetCurrentUser but honors ctx cancellation
+func (c *Client) GetCurrentUserContext(ctx context.Context) (*models.TeamMember, error) {
+	resp, err := c.getWithBaseAndVersion(ctx, c.collectionURL, "/_apis/connectionData", apiVersionPreview)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp connectionDataResponse
+	if err := decode(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	user := apiResp.AuthenticatedUser
+	return &models.TeamMember{
+		ID:          user.ID,
+		DisplayName: user.ProviderDisplayName,
+		UniqueName:  user.Properties.Account.Value,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// MaxWIQLResults is the number of work items a raw WIQL query returns at most
+const MaxWIQLResults = 1000
+
+// wiqlAnyResponse is the response from a WIQL query on either work items or links
+type wiqlAnyResponse struct {
+	wiqlResponse
+	wiqlLinksResponse
+}
+
+// QueryWIQL runs a WIQL query as written and returns the work items it matched, in
+// the query's order. For queries on links, the linked items are listed once each,
+// along with the parent-child links among them. Syntax errors come back as an
+// *APIError carrying the server's message.
+func (c *Client) QueryWIQL(query string) ([]models.WorkItem, []models.HierarchyLink, error) {
+	return c.QueryWIQLContext(context.Background(), query)
+}
+
+// QueryWIQLContext is like QueryWIQL but honors ctx cancellation
+func (c *Client) QueryWIQLContext(ctx context.Context, query string) ([]models.WorkItem, []models.HierarchyLink, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil, fmt.Errorf("empty WIQL query")
+	}
+
+	bodyBytes, err := json.Marshal(wiqlRequest{Query: query})
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshaling WIQL request: %w", err)
+	}
+
+	// Date conditions compare times, so "changed in the last hour" works as written
+	endpoint := fmt.Sprintf("/wit/wiql?timePrecision=true&$top=%d", MaxWIQLResults)
+	resp, err := c.postQuery(ctx, endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var wiqlResp wiqlAnyResponse
+	if err := decode(resp, &wiqlResp); err != nil {
+		return nil, nil, err
+	}
+
+	var ids []string
+	seen := make(map[int]bool)
+	add := func(id int) {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, fmt.Sprintf("%d", id))
+		}
+	}
+	for _, wi := range wiqlResp.WorkItems {
+		add(wi.ID)
+	}
+	var links []models.HierarchyLink
+	for _, rel := range wiqlResp.WorkItemRelations {
+		if rel.Source == nil {
+			add(rel.Target.ID)
+			continue
+		}
+		add(rel.Source.ID)
+		add(rel.Target.ID)
+		if rel.Rel == "System.LinkTypes.Hierarchy-Forward" {
+			links = append(links, models.HierarchyLink{ParentID: rel.Source.ID, ChildID: rel.Target.ID})
+		}
+	}
+
+	items, err := c.GetWorkItemsContext(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, links, nil
+}
@@ -2,9 +2,12 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -87,29 +90,7 @@ type workItemFields struct {
 	Risk               string  `json:"Microsoft.VSTS.Common.Risk"`
 	BoardColumn        string  `json:"System.BoardColumn"`
 	BoardColumnDone    bool    `json:"System.BoardColumnDone"`
-}
-
-// commentsResponse represents the response from the comments API
-type commentsResponse struct {
-	TotalCount int              `json:"totalCount"`
-	Count      int              `json:"count"`
-	Comments   []commentAPIItem `json:"comments"`
-}
-
-type commentAPIItem struct {
-	ID         int    `json:"id"`
-	WorkItemID int    `json:"workItemId"`
-	Text       string `json:"text"`
-	CreatedBy  *struct {
-		DisplayName string `json:"displayName"`
-		UniqueName  string `json:"uniqueName"`
-	} `json:"createdBy"`
-	CreatedDate time.Time `json:"createdDate"`
-	ModifiedBy  *struct {
-		DisplayName string `json:"displayName"`
-		UniqueName  string `json:"uniqueName"`
-	} `json:"modifiedBy"`
-	ModifiedDate time.Time `json:"modifiedDate"`
+	BoardLane          string  `json:"System.BoardLane"`
 }
 
 // escapeWIQL escapes a string value for use in WIQL queries
@@ -120,38 +101,16 @@ func escapeWIQL(s string) string {
 
 // QueryWorkItems queries work items using WIQL
 func (c *Client) QueryWorkItems(sprintPath, state, assigned, areaPath string) ([]models.WorkItem, error) {
+	return c.QueryWorkItemsContext(context.Background(), sprintPath, state, assigned, areaPath)
+}
+
+// QueryWorkItemsContext is like QueryWorkItems but honors ctx cancellation
+func (c *Client) QueryWorkItemsContext(ctx context.Context, sprintPath, state, assigned, areaPath string) ([]models.WorkItem, error) {
 	// Build WIQL query
 	query := `SELECT [System.Id], [System.Title], [System.State], [System.WorkItemType]
 FROM WorkItems
 WHERE [System.TeamProject] = @project`
-
-	// Add sprint filter
-	if sprintPath != "" && sprintPath != "all" {
-		query += fmt.Sprintf(`
-  AND [System.IterationPath] = '%s'`, escapeWIQL(sprintPath))
-	}
-
-	// Add state filter
-	if state != "" && state != "all" {
-		query += fmt.Sprintf(`
-  AND [System.State] = '%s'`, escapeWIQL(state))
-	}
-
-	// Add assigned filter
-	if assigned == "me" {
-		query += `
-  AND [System.AssignedTo] = @me`
-	}
-
-	// Add area filter
-	if areaPath != "" && areaPath != "all" {
-		// Clean up the path
-		areaPath = strings.TrimPrefix(areaPath, "\\")
-		areaPath = strings.TrimSuffix(areaPath, "\\")
-		query += fmt.Sprintf(`
-  AND [System.AreaPath] UNDER '%s'`, escapeWIQL(areaPath))
-	}
-
+	query += filterClauses("", sprintPath, state, assigned, areaPath)
 	query += `
 ORDER BY [System.ChangedDate] DESC`
 
@@ -162,7 +121,7 @@ ORDER BY [System.ChangedDate] DESC`
 		return nil, fmt.Errorf("marshaling WIQL request: %w", err)
 	}
 
-	resp, err := c.post("/wit/wiql", bytes.NewReader(bodyBytes))
+	resp, err := c.postQuery(ctx, "/wit/wiql", bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
@@ -183,7 +142,42 @@ ORDER BY [System.ChangedDate] DESC`
 	}
 
 	// Fetch the full work items
-	return c.GetWorkItems(ids)
+	return c.GetWorkItemsContext(ctx, ids)
+}
+
+// filterClauses returns the WIQL conditions for the list filters. prefix qualifies
+// the fields, e.g. "[Source]." in link queries.
+func filterClauses(prefix, sprintPath, state, assigned, areaPath string) string {
+	var query string
+
+	// Add sprint filter
+	if sprintPath != "" && sprintPath != "all" {
+		query += fmt.Sprintf(`
+  AND %s[System.IterationPath] = '%s'`, prefix, escapeWIQL(sprintPath))
+	}
+
+	// Add state filter
+	if state != "" && state != "all" {
+		query += fmt.Sprintf(`
+  AND %s[System.State] = '%s'`, prefix, escapeWIQL(state))
+	}
+
+	// Add assigned filter
+	if assigned == "me" {
+		query += fmt.Sprintf(`
+  AND %s[System.AssignedTo] = @me`, prefix)
+	}
+
+	// Add area filter
+	if areaPath != "" && areaPath != "all" {
+		// Clean up the path
+		areaPath = strings.TrimPrefix(areaPath, "\\")
+		areaPath = strings.TrimSuffix(areaPath, "\\")
+		query += fmt.Sprintf(`
+  AND %s[System.AreaPath] UNDER '%s'`, prefix, escapeWIQL(areaPath))
+	}
+
+	return query
 }
 
 // allWorkItemFields returns all fields we want to fetch
@@ -205,6 +199,7 @@ func allWorkItemFields() string {
 		"System.CommentCount",
 		"System.BoardColumn",
 		"System.BoardColumnDone",
+		"System.BoardLane",
 		"System.CreatedDate",
 		"System.ChangedDate",
 		"Microsoft.VSTS.Common.Priority",
@@ -224,6 +219,11 @@ func allWorkItemFields() string {
 
 // GetWorkItems fetches multiple work items by ID
 func (c *Client) GetWorkItems(ids []string) ([]models.WorkItem, error) {
+	return c.GetWorkItemsContext(context.Background(), ids)
+}
+
+// GetWorkItemsContext is like GetWorkItems but honors ctx cancellation
+func (c *Client) GetWorkItemsContext(ctx context.Context, ids []string) ([]models.WorkItem, error) {
 	if len(ids) == 0 {
 		return []models.WorkItem{}, nil
 	}
@@ -243,7 +243,7 @@ func (c *Client) GetWorkItems(ids []string) ([]models.WorkItem, error) {
 
 		// Note: Can't use $expand=relations with fields parameter
 		endpoint := fmt.Sprintf("/wit/workitems?ids=%s&fields=%s", strings.Join(batch, ","), fields)
-		resp, err := c.get(endpoint)
+		resp, err := c.get(ctx, endpoint)
 		if err != nil {
 			return nil, err
 		}
@@ -260,13 +260,13 @@ func (c *Client) GetWorkItems(ids []string) ([]models.WorkItem, error) {
 	}
 
 	// Fetch parent titles
-	c.populateParentTitles(allItems)
+	c.populateParentTitles(ctx, allItems)
 
 	return allItems, nil
 }
 
 // populateParentTitles fetches titles for all parent work items
-func (c *Client) populateParentTitles(items []models.WorkItem) {
+func (c *Client) populateParentTitles(ctx context.Context, items []models.WorkItem) {
 	// Collect unique parent IDs
 	parentIDs := make(map[int]bool)
 	for _, item := range items {
@@ -287,7 +287,7 @@ func (c *Client) populateParentTitles(items []models.WorkItem) {
 
 	// Fetch parent work items (only need ID and Title)
 	endpoint := fmt.Sprintf("/wit/workitems?ids=%s&fields=System.Id,System.Title", strings.Join(ids, ","))
-	resp, err := c.get(endpoint)
+	resp, err := c.get(ctx, endpoint)
 	if err != nil {
 		return // Silently fail - parent titles are optional
 	}
@@ -315,9 +315,14 @@ func (c *Client) populateParentTitles(items []models.WorkItem) {
 
 // GetWorkItem fetches a single work item by ID with full details
 func (c *Client) GetWorkItem(id int) (*models.WorkItem, error) {
+	return c.GetWorkItemContext(context.Background(), id)
+}
+
+// GetWorkItemContext is like GetWorkItem but honors ctx cancellation
+func (c *Client) GetWorkItemContext(ctx context.Context, id int) (*models.WorkItem, error) {
 	// Use $expand=all to get relations - can't combine with fields parameter
 	endpoint := fmt.Sprintf("/wit/workitems/%d?$expand=all", id)
-	resp, err := c.get(endpoint)
+	resp, err := c.get(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -332,7 +337,7 @@ func (c *Client) GetWorkItem(id int) (*models.WorkItem, error) {
 	// Fetch parent title if parent exists
 	if wi.ParentID > 0 {
 		parentEndpoint := fmt.Sprintf("/wit/workitems/%d?fields=System.Title", wi.ParentID)
-		parentResp, err := c.get(parentEndpoint)
+		parentResp, err := c.get(ctx, parentEndpoint)
 		if err == nil {
 			var parentItem workItemAPIItem
 			if decode(parentResp, &parentItem) == nil {
@@ -342,7 +347,7 @@ func (c *Client) GetWorkItem(id int) (*models.WorkItem, error) {
 	}
 
 	// Always fetch comments - CommentCount may not be reliable with $expand=all
-	comments, err := c.GetWorkItemComments(id)
+	comments, err := c.GetWorkItemCommentsContext(ctx, id)
 	if err == nil {
 		wi.Comments = comments
 		// Update comment count from actual fetched comments
@@ -352,46 +357,16 @@ func (c *Client) GetWorkItem(id int) (*models.WorkItem, error) {
 	}
 
 	// Populate related links with details
-	c.populateRelatedLinks(&wi)
+	c.populateRelatedLinks(ctx, &wi)
 
-	return &wi, nil
-}
+	// Pull request status and commit messages come from the Git API
+	c.populateDevelopmentLinks(ctx, &wi)
 
-// GetWorkItemComments fetches comments for a work item
-func (c *Client) GetWorkItemComments(id int) ([]models.Comment, error) {
-	endpoint := fmt.Sprintf("/wit/workitems/%d/comments", id)
-	resp, err := c.getPreview(endpoint)
-	if err != nil {
-		return nil, err
-	}
-
-	var apiResp commentsResponse
-	if err := decode(resp, &apiResp); err != nil {
-		return nil, err
-	}
-
-	comments := make([]models.Comment, 0, len(apiResp.Comments))
-	for _, c := range apiResp.Comments {
-		comment := models.Comment{
-			ID:           c.ID,
-			Text:         stripHTML(c.Text),
-			CreatedDate:  c.CreatedDate,
-			ModifiedDate: c.ModifiedDate,
-		}
-		if c.CreatedBy != nil {
-			comment.CreatedBy = c.CreatedBy.DisplayName
-		}
-		if c.ModifiedBy != nil {
-			comment.ModifiedBy = c.ModifiedBy.DisplayName
-		}
-		comments = append(comments, comment)
-	}
-
-	return comments, nil
+	return &wi, nil
 }
 
 // populateRelatedLinks fetches details for related work items
-func (c *Client) populateRelatedLinks(item *models.WorkItem) {
+func (c *Client) populateRelatedLinks(ctx context.Context, item *models.WorkItem) {
 	if len(item.RelatedLinks) == 0 {
 		return
 	}
@@ -410,7 +385,7 @@ func (c *Client) populateRelatedLinks(item *models.WorkItem) {
 
 	// Fetch related work items
 	endpoint := fmt.Sprintf("/wit/workitems?ids=%s&fields=System.Id,System.Title,System.State,System.WorkItemType", strings.Join(ids, ","))
-	resp, err := c.get(endpoint)
+	resp, err := c.get(ctx, endpoint)
 	if err != nil {
 		return
 	}
@@ -470,6 +445,7 @@ func (c *Client) convertWorkItem(item workItemAPIItem) models.WorkItem {
 		Risk:               item.Fields.Risk,
 		BoardColumn:        item.Fields.BoardColumn,
 		BoardColumnDone:    item.Fields.BoardColumnDone,
+		BoardLane:          item.Fields.BoardLane,
 	}
 
 	if item.Fields.AssignedTo != nil {
@@ -499,6 +475,14 @@ func (c *Client) convertWorkItem(item workItemAPIItem) models.WorkItem {
 	// Parse relations
 	if len(item.Relations) > 0 {
 		for _, rel := range item.Relations {
+			if rel.Rel == attachmentRel {
+				wi.Attachments = append(wi.Attachments, parseAttachment(rel))
+				continue
+			}
+			if dev := parseArtifactLink(rel); dev != nil {
+				wi.DevelopmentLinks = append(wi.DevelopmentLinks, *dev)
+				continue
+			}
 			link := c.parseRelation(rel)
 			if link != nil {
 				if link.LinkType == "Child" {
@@ -578,23 +562,61 @@ func extractWorkItemID(url string) int {
 }
 
 // UpdateWorkItemState updates a work item's state
-func (c *Client) UpdateWorkItemState(id int, newState string) error {
+// rev is the revision the change is based on; pass 0 to skip the concurrency check
+func (c *Client) UpdateWorkItemState(id, rev int, newState string) error {
+	return c.UpdateWorkItemStateContext(context.Background(), id, rev, newState)
+}
+
+// UpdateWorkItemStateContext is like UpdateWorkItemState but honors ctx cancellation
+func (c *Client) UpdateWorkItemStateContext(ctx context.Context, id, rev int, newState string) error {
+	return c.UpdateFieldsContext(ctx, id, rev, map[string]interface{}{
+		"System.State": newState,
+	})
+}
+
+// AssignWorkItem assigns a work item to a user
+// Pass empty string to unassign
+func (c *Client) AssignWorkItem(id, rev int, userEmail string) error {
+	return c.AssignWorkItemContext(context.Background(), id, rev, userEmail)
+}
+
+// AssignWorkItemContext is like AssignWorkItem but honors ctx cancellation
+func (c *Client) AssignWorkItemContext(ctx context.Context, id, rev int, userEmail string) error {
+	return c.UpdateFieldsContext(ctx, id, rev, map[string]interface{}{
+		"System.AssignedTo": userEmail,
+	})
+}
+
+// UpdateFields patches the given fields (reference name -> value) of a work item.
+// rev is the revision the change is based on; pass 0 to skip the concurrency check.
+// A nil value removes the field; the server rejects that if the field isn't set.
+func (c *Client) UpdateFields(id, rev int, fields map[string]interface{}) error {
+	return c.UpdateFieldsContext(context.Background(), id, rev, fields)
+}
+
+// UpdateFieldsContext is like UpdateFields but honors ctx cancellation.
+// If rev is non-zero the patch starts with a "test /rev" operation, so the server rejects
+// it with a RevisionConflictError when someone else changed the item in the meantime.
+func (c *Client) UpdateFieldsContext(ctx context.Context, id, rev int, fields map[string]interface{}) error {
 	// Azure DevOps uses JSON Patch format
-	patchDoc := []map[string]interface{}{
-		{
-			"op":    "add",
-			"path":  "/fields/System.State",
-			"value": newState,
-		},
+	patchDoc := make([]map[string]interface{}, 0, len(fields)+1)
+	if rev > 0 {
+		patchDoc = append(patchDoc, map[string]interface{}{
+			"op":    "test",
+			"path":  "/rev",
+			"value": rev,
+		})
 	}
 
+	patchDoc = append(patchDoc, fieldPatchOps(fields)...)
+
 	bodyBytes, err := json.Marshal(patchDoc)
 	if err != nil {
 		return fmt.Errorf("marshaling patch document: %w", err)
 	}
 
 	endpoint := fmt.Sprintf("/wit/workitems/%d", id)
-	resp, err := c.patch(endpoint, bytes.NewReader(bodyBytes))
+	resp, err := c.patch(ctx, endpoint, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return err
 	}
@@ -603,31 +625,105 @@ func (c *Client) UpdateWorkItemState(id int, newState string) error {
 	return nil
 }
 
-// AssignWorkItem assigns a work item to a user
-// Pass empty string to unassign
-func (c *Client) AssignWorkItem(id int, userEmail string) error {
-	// Azure DevOps uses JSON Patch format
-	patchDoc := []map[string]interface{}{
-		{
+// GetWorkItemRawFields fetches the unprocessed values of string fields, e.g. the HTML
+// of System.Description
+func (c *Client) GetWorkItemRawFields(id int, fields []string) (map[string]string, error) {
+	return c.GetWorkItemRawFieldsContext(context.Background(), id, fields)
+}
+
+// GetWorkItemRawFieldsContext is like GetWorkItemRawFields but honors ctx cancellation
+func (c *Client) GetWorkItemRawFieldsContext(ctx context.Context, id int, fields []string) (map[string]string, error) {
+	endpoint := fmt.Sprintf("/wit/workitems/%d?fields=%s", id, strings.Join(fields, ","))
+	resp, err := c.get(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var item struct {
+		Fields map[string]interface{} `json:"fields"`
+	}
+	if err := decode(resp, &item); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(fields))
+	for _, field := range fields {
+		if v, ok := item.Fields[field].(string); ok {
+			values[field] = v
+		}
+	}
+	return values, nil
+}
+
+// fieldPatchOps builds JSON Patch operations setting the given fields. A nil value
+// removes the field.
+func fieldPatchOps(fields map[string]interface{}) []map[string]interface{} {
+	// Sort for a deterministic patch document
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ops := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		if fields[name] == nil {
+			ops = append(ops, map[string]interface{}{
+				"op":   "remove",
+				"path": "/fields/" + name,
+			})
+			continue
+		}
+		ops = append(ops, map[string]interface{}{
 			"op":    "add",
-			"path":  "/fields/System.AssignedTo",
-			"value": userEmail,
-		},
+			"path":  "/fields/" + name,
+			"value": fields[name],
+		})
+	}
+	return ops
+}
+
+// CreateWorkItem creates a work item of the given type. If parentID is non-zero the
+// new item is added as a child of that work item.
+func (c *Client) CreateWorkItem(workItemType string, fields map[string]interface{}, parentID int) (*models.WorkItem, error) {
+	return c.CreateWorkItemContext(context.Background(), workItemType, fields, parentID)
+}
+
+// CreateWorkItemContext is like CreateWorkItem but honors ctx cancellation
+func (c *Client) CreateWorkItemContext(ctx context.Context, workItemType string, fields map[string]interface{}, parentID int) (*models.WorkItem, error) {
+	patchDoc := fieldPatchOps(fields)
+	if parentID > 0 {
+		patchDoc = append(patchDoc, map[string]interface{}{
+			"op":   "add",
+			"path": "/relations/-",
+			"value": map[string]interface{}{
+				"rel": "System.LinkTypes.Hierarchy-Reverse",
+				"url": c.workItemAPIURL(parentID),
+			},
+		})
 	}
 
 	bodyBytes, err := json.Marshal(patchDoc)
 	if err != nil {
-		return fmt.Errorf("marshaling patch document: %w", err)
+		return nil, fmt.Errorf("marshaling patch document: %w", err)
 	}
 
-	endpoint := fmt.Sprintf("/wit/workitems/%d", id)
-	resp, err := c.patch(endpoint, bytes.NewReader(bodyBytes))
+	endpoint := "/wit/workitems/$" + url.PathEscape(workItemType)
+	resp, err := c.postPatch(ctx, endpoint, bytes.NewReader(bodyBytes))
 	if err != nil {
-		return err
+		return nil, err
 	}
-	resp.Body.Close()
 
-	return nil
+	var item workItemAPIItem
+	if err := decode(resp, &item); err != nil {
+		return nil, err
+	}
+
+	wi := c.convertWorkItem(item)
+	if wi.ParentID == 0 {
+		wi.ParentID = parentID
+	}
+	return &wi, nil
 }
 
 // stripHTML removes HTML tags from a string
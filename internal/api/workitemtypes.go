@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/samuelenocsson/devops-tui/internal/models"
@@ -37,7 +38,12 @@ type stateAPIItem struct {
 
 // GetWorkItemTypes fetches all work item types for the project
 func (c *Client) GetWorkItemTypes() ([]string, error) {
-	resp, err := c.get("/wit/workitemtypes")
+	return c.GetWorkItemTypesContext(context.Background())
+}
+
+// GetWorkItemTypesContext is like GetWorkItemTypes but honors ctx cancellation
+func (c *Client) GetWorkItemTypesContext(ctx context.Context) ([]string, error) {
+	resp, err := c.get(ctx, "/wit/workitemtypes")
 	if err != nil {
 		return nil, err
 	}
@@ -57,8 +63,13 @@ func (c *Client) GetWorkItemTypes() ([]string, error) {
 
 // GetWorkItemTypeStates fetches all states for a specific work item type
 func (c *Client) GetWorkItemTypeStates(workItemType string) ([]models.WorkItemStateInfo, error) {
+	return c.GetWorkItemTypeStatesContext(context.Background(), workItemType)
+}
+
+// GetWorkItemTypeStatesContext is like GetWorkItemTypeStates but honors ctx cancellation
+func (c *Client) GetWorkItemTypeStatesContext(ctx context.Context, workItemType string) ([]models.WorkItemStateInfo, error) {
 	endpoint := fmt.Sprintf("/wit/workitemtypes/%s/states", workItemType)
-	resp, err := c.get(endpoint)
+	resp, err := c.get(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -82,15 +93,23 @@ func (c *Client) GetWorkItemTypeStates(workItemType string) ([]models.WorkItemSt
 
 // GetAllWorkItemTypeStates fetches states for all work item types
 func (c *Client) GetAllWorkItemTypeStates() (map[string][]models.WorkItemStateInfo, error) {
-	types, err := c.GetWorkItemTypes()
+	return c.GetAllWorkItemTypeStatesContext(context.Background())
+}
+
+// GetAllWorkItemTypeStatesContext is like GetAllWorkItemTypeStates but honors ctx cancellation
+func (c *Client) GetAllWorkItemTypeStatesContext(ctx context.Context) (map[string][]models.WorkItemStateInfo, error) {
+	types, err := c.GetWorkItemTypesContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	statesByType := make(map[string][]models.WorkItemStateInfo)
 	for _, t := range types {
-		states, err := c.GetWorkItemTypeStates(t)
+		states, err := c.GetWorkItemTypeStatesContext(ctx, t)
 		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
 			// Skip types that fail (some system types may not have states)
 			continue
 		}
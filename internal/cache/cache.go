@@ -0,0 +1,224 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// version is bumped when the file layout changes; older files are ignored
+const version = 1
+
+// maxQueries is the number of query results kept; the least recently synced are
+// dropped along with the work items only they list
+const maxQueries = 20
+
+// Store is the on-disk copy of what was last loaded from Azure DevOps, so it can be
+// shown at startup and while offline. It is safe for concurrent use.
+type Store struct {
+	path string
+
+	mu   sync.Mutex
+	data snapshot
+}
+
+// snapshot is the cache file's content
+type snapshot struct {
+	Version  int                     `json:"version"`
+	Metadata *Metadata               `json:"metadata,omitempty"`
+	Items    map[int]models.WorkItem `json:"items"`
+	Queries  map[string]Query        `json:"queries"`
+}
+
+// Metadata is the project data the filters and modals are built from
+type Metadata struct {
+	Iterations   []models.Iteration                    `json:"iterations"`
+	Areas        []models.Area                         `json:"areas"`
+	StatesByType map[string][]models.WorkItemStateInfo `json:"statesByType"`
+	TeamMembers  []models.TeamMember                   `json:"teamMembers"`
+	CurrentUser  *models.TeamMember                    `json:"currentUser,omitempty"`
+	SyncedAt     time.Time                             `json:"syncedAt"`
+}
+
+// Query is the result of a work item query as it was last loaded
+type Query struct {
+	IDs      []int                  `json:"ids"`   // In list order
+	Links    []models.HierarchyLink `json:"links"` // Parent-child links, for tree queries
+	SyncedAt time.Time              `json:"syncedAt"`
+}
+
+// Open returns the store of a project and team, kept under dir. Nothing is read
+// until Load.
+func Open(dir, collectionURL, project, team string) *Store {
+	sum := sha256.Sum256([]byte(collectionURL + "\n" + project + "\n" + team))
+	return &Store{
+		path: filepath.Join(dir, "cache", fmt.Sprintf("%x.json", sum[:8])),
+		data: emptySnapshot(),
+	}
+}
+
+func emptySnapshot() snapshot {
+	return snapshot{
+		Version: version,
+		Items:   make(map[int]models.WorkItem),
+		Queries: make(map[string]Query),
+	}
+}
+
+// QueryKey identifies a work item query by its filters
+func QueryKey(sprint, state, assigned, area string, tree bool) string {
+	return strings.Join([]string{sprint, state, assigned, area, fmt.Sprint(tree)}, "|")
+}
+
+// WIQLKey identifies a raw WIQL query
+func WIQLKey(query string) string {
+	return "wiql|" + strings.TrimSpace(query)
+}
+
+// Load reads the cache file. A missing, corrupt or outdated file leaves the store
+// empty.
+func (s *Store) Load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading cache: %w", err)
+	}
+
+	snap := emptySnapshot()
+	if err := json.Unmarshal(data, &snap); err != nil || snap.Version != version {
+		return nil
+	}
+	if snap.Items == nil {
+		snap.Items = make(map[int]models.WorkItem)
+	}
+	if snap.Queries == nil {
+		snap.Queries = make(map[string]Query)
+	}
+
+	// Keep what was stored since the store was opened; it's newer
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data.Metadata != nil {
+		snap.Metadata = s.data.Metadata
+	}
+	for key, q := range s.data.Queries {
+		snap.Queries[key] = q
+	}
+	for id, item := range s.data.Items {
+		snap.Items[id] = item
+	}
+	s.data = snap
+	return nil
+}
+
+// Save writes the cache file, replacing it at once so a crash can't leave it
+// half written
+func (s *Store) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune()
+	data, err := json.Marshal(s.data)
+	if err != nil {
+		return fmt.Errorf("encoding cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("writing cache: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("writing cache: %w", err)
+	}
+	return nil
+}
+
+// prune drops the least recently synced queries beyond maxQueries and the work
+// items no query lists. Call with mu held.
+func (s *Store) prune() {
+	if len(s.data.Queries) > maxQueries {
+		keys := make([]string, 0, len(s.data.Queries))
+		for key := range s.data.Queries {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return s.data.Queries[keys[i]].SyncedAt.After(s.data.Queries[keys[j]].SyncedAt)
+		})
+		for _, key := range keys[maxQueries:] {
+			delete(s.data.Queries, key)
+		}
+	}
+
+	listed := make(map[int]bool, len(s.data.Items))
+	for _, q := range s.data.Queries {
+		for _, id := range q.IDs {
+			listed[id] = true
+		}
+	}
+	for id := range s.data.Items {
+		if !listed[id] {
+			delete(s.data.Items, id)
+		}
+	}
+}
+
+// Metadata returns the cached project data, or nil if there is none
+func (s *Store) Metadata() *Metadata {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.Metadata
+}
+
+// SetMetadata replaces the cached project data
+func (s *Store) SetMetadata(m Metadata) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Metadata = &m
+}
+
+// Query returns the cached result of a query, with its work items in list order.
+// ok is false if the query isn't cached or some of its items are missing.
+func (s *Store) Query(key string) (items []models.WorkItem, q Query, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q, ok = s.data.Queries[key]
+	if !ok {
+		return nil, Query{}, false
+	}
+	items = make([]models.WorkItem, 0, len(q.IDs))
+	for _, id := range q.IDs {
+		item, found := s.data.Items[id]
+		if !found {
+			return nil, Query{}, false
+		}
+		items = append(items, item)
+	}
+	return items, q, true
+}
+
+// PutQuery stores the result of a query, loaded as of syncedAt
+func (s *Store) PutQuery(key string, items []models.WorkItem, links []models.HierarchyLink, syncedAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+		s.data.Items[item.ID] = item
+	}
+	s.data.Queries[key] = Query{IDs: ids, Links: links, SyncedAt: syncedAt}
+}
@@ -2,8 +2,11 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -16,14 +19,24 @@ const (
 	AuthMethodOAuth AuthMethod = "oauth"
 )
 
+// MinRefreshInterval is the shortest allowed background refresh interval
+const MinRefreshInterval = 10 * time.Second
+
 // Config holds the application configuration
 type Config struct {
 	Organization string   `mapstructure:"organization"`
 	Project      string   `mapstructure:"project"`
 	Team         string   `mapstructure:"team"`
+	ServerURL    string   `mapstructure:"server_url"`  // Azure DevOps Server collection URL (empty for dev.azure.com)
+	APIVersion   string   `mapstructure:"api_version"` // REST API version override (negotiated when empty)
 	PAT          string   `mapstructure:"pat"`
 	Theme        string   `mapstructure:"theme"`
+	DownloadDir  string   `mapstructure:"download_dir"` // Where attachments are saved (defaults to ~/Downloads)
 	Defaults     Defaults `mapstructure:"defaults"`
+
+	// How often the work item list is re-queried in the background (0 disables)
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+
 	// Runtime fields (not from config file)
 	AuthMethod  AuthMethod `mapstructure:"-"`
 	AccessToken string     `mapstructure:"-"`
@@ -74,14 +87,27 @@ func Load() (*Config, error) {
 	v.BindEnv("organization", "AZURE_DEVOPS_ORG")
 	v.BindEnv("project", "AZURE_DEVOPS_PROJECT")
 	v.BindEnv("team", "AZURE_DEVOPS_TEAM")
+	v.BindEnv("server_url", "AZURE_DEVOPS_SERVER_URL")
 
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
 
+	cfg.ServerURL = strings.TrimRight(strings.TrimSpace(cfg.ServerURL), "/")
+	if cfg.ServerURL != "" {
+		if u, err := url.Parse(cfg.ServerURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("server_url must be an absolute URL like https://tfs.example.com/tfs/DefaultCollection")
+		}
+	}
+
+	if cfg.RefreshInterval < 0 || (cfg.RefreshInterval > 0 && cfg.RefreshInterval < MinRefreshInterval) {
+		return nil, fmt.Errorf("refresh_interval must be 0 (off) or at least %s", MinRefreshInterval)
+	}
+
 	// Validate required fields (excluding PAT - that's optional now)
-	if cfg.Organization == "" {
+	// On-prem servers identify the collection by URL, so organization is optional there
+	if cfg.Organization == "" && cfg.ServerURL == "" {
 		return nil, fmt.Errorf("organization is required (set in config or AZURE_DEVOPS_ORG)")
 	}
 	if cfg.Project == "" {
@@ -131,19 +157,41 @@ func (c *Config) IsPAT() bool {
 	return c.AuthMethod == AuthMethodPAT
 }
 
+// IsOnPrem returns true if the config targets an Azure DevOps Server collection
+func (c *Config) IsOnPrem() bool {
+	return c.ServerURL != ""
+}
+
+// CollectionURL returns the organization (hosted) or collection (on-prem) URL
+func (c *Config) CollectionURL() string {
+	if c.IsOnPrem() {
+		return c.ServerURL
+	}
+	return fmt.Sprintf("https://dev.azure.com/%s", c.Organization)
+}
+
+// CollectionName returns the organization name, or the collection name for on-prem servers
+func (c *Config) CollectionName() string {
+	if c.Organization != "" || !c.IsOnPrem() {
+		return c.Organization
+	}
+	// https://tfs.corp.local/tfs/DefaultCollection -> DefaultCollection
+	return c.ServerURL[strings.LastIndex(c.ServerURL, "/")+1:]
+}
+
 // BaseURL returns the Azure DevOps API base URL
 func (c *Config) BaseURL() string {
-	return fmt.Sprintf("https://dev.azure.com/%s/%s/_apis", c.Organization, c.Project)
+	return fmt.Sprintf("%s/%s/_apis", c.CollectionURL(), url.PathEscape(c.Project))
 }
 
 // TeamURL returns the Azure DevOps API URL for team-specific endpoints
 func (c *Config) TeamURL() string {
-	return fmt.Sprintf("https://dev.azure.com/%s/%s/%s/_apis", c.Organization, c.Project, c.Team)
+	return fmt.Sprintf("%s/%s/%s/_apis", c.CollectionURL(), url.PathEscape(c.Project), url.PathEscape(c.Team))
 }
 
 // WebURL returns the Azure DevOps web URL for the project
 func (c *Config) WebURL() string {
-	return fmt.Sprintf("https://dev.azure.com/%s/%s", c.Organization, c.Project)
+	return fmt.Sprintf("%s/%s", c.CollectionURL(), url.PathEscape(c.Project))
 }
 
 // CreateDefaultConfig creates a default config file
@@ -170,15 +218,26 @@ organization: "my-organization"
 project: "my-project"
 team: "my-team"
 
+# Azure DevOps Server (on-prem) collection URL - leave empty for dev.azure.com
+# server_url: "https://tfs.example.com/tfs/DefaultCollection"
+# REST API version - leave empty to negotiate with the server
+# api_version: "7.0"
+
 # Authentication
 # PAT can be set here or via environment variable AZURE_DEVOPS_PAT
 # If no PAT is provided, the tool will use OAuth device flow
-# to authenticate interactively via your browser
+# to authenticate interactively via your browser (dev.azure.com only)
 pat: ""
 
 # UI settings
 theme: "default"  # default, dark, light
 
+# Directory attachments are downloaded to (defaults to ~/Downloads)
+# download_dir: "~/Downloads"
+
+# Re-run the work item query in the background and highlight what changed
+# refresh_interval: "2m"  # e.g. "30s", "5m"; 0 or unset disables
+
 # Default filters at startup
 defaults:
   sprint: "current"      # "current", "all", or specific name
@@ -189,6 +248,26 @@ defaults:
 	return os.WriteFile(configPath, []byte(content), 0600)
 }
 
+// AttachmentDir returns the directory attachments are downloaded to. A leading
+// "~" in download_dir is expanded; without it, ~/Downloads is used if it exists.
+func (c *Config) AttachmentDir() string {
+	home, _ := os.UserHomeDir()
+
+	dir := strings.TrimSpace(c.DownloadDir)
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		return filepath.Join(home, dir[1:])
+	}
+	if dir != "" {
+		return dir
+	}
+
+	downloads := filepath.Join(home, "Downloads")
+	if info, err := os.Stat(downloads); err == nil && info.IsDir() {
+		return downloads
+	}
+	return "."
+}
+
 // GetConfigDir returns the configuration directory path
 func GetConfigDir() string {
 	home, err := os.UserHomeDir()
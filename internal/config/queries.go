@@ -0,0 +1,79 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxQueryHistory is the number of recent WIQL queries kept
+const MaxQueryHistory = 20
+
+// getQueryHistoryPath returns the path to the WIQL query history file
+func getQueryHistoryPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "devops-tui", "queries.json"), nil
+}
+
+// LoadQueryHistory loads the recent WIQL queries, most recent first
+func LoadQueryHistory() ([]string, error) {
+	path, err := getQueryHistoryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var queries []string
+	if err := json.Unmarshal(data, &queries); err != nil {
+		// Start over if the file is corrupted
+		return nil, nil
+	}
+	return queries, nil
+}
+
+// SaveQueryHistory saves the recent WIQL queries to disk
+func SaveQueryHistory(queries []string) error {
+	path, err := getQueryHistoryPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(queries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+// AddToQueryHistory puts query first in history, dropping an earlier copy of it and
+// the oldest queries beyond MaxQueryHistory
+func AddToQueryHistory(history []string, query string) []string {
+	query = strings.TrimSpace(query)
+	updated := make([]string, 0, len(history)+1)
+	updated = append(updated, query)
+	for _, q := range history {
+		if strings.TrimSpace(q) != query {
+			updated = append(updated, q)
+		}
+	}
+	if len(updated) > MaxQueryHistory {
+		updated = updated[:MaxQueryHistory]
+	}
+	return updated
+}
@@ -0,0 +1,75 @@
+package models
+
+// Board is a team's Kanban board for one backlog level
+type Board struct {
+	ID      string        `json:"id"`
+	Name    string        `json:"name"`
+	Columns []BoardColumn `json:"columns"`
+	Rows    []BoardRow    `json:"rows"` // Swimlanes; the default lane has an empty name
+
+	// Reference names of the board's own fields, e.g. "WEF_..._Kanban.Column"
+	ColumnField string `json:"columnField"`
+	DoneField   string `json:"doneField"`
+	RowField    string `json:"rowField"`
+}
+
+// BoardColumn is a column of a Kanban board
+type BoardColumn struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	ItemLimit     int               `json:"itemLimit"` // WIP limit, 0 if none
+	IsSplit       bool              `json:"isSplit"`   // Split into Doing and Done
+	ColumnType    string            `json:"columnType"`
+	StateMappings map[string]string `json:"stateMappings"` // Work item type to state
+}
+
+// BoardRow is a swimlane of a Kanban board
+type BoardRow struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// HasWIPLimit reports whether the column limits the items in progress. The first
+// (incoming) and last (outgoing) columns never do.
+func (c *BoardColumn) HasWIPLimit() bool {
+	return c.ItemLimit > 0 && c.ColumnType != "incoming" && c.ColumnType != "outgoing"
+}
+
+// Accepts reports whether items of the given type can be placed in the column
+func (c *BoardColumn) Accepts(workItemType WorkItemType) bool {
+	_, ok := c.StateMappings[string(workItemType)]
+	return ok
+}
+
+// ColumnOf returns the index of the column an item is in: its board column if it is
+// on this board, otherwise the first column mapped to its state. Returns -1 if the
+// item doesn't belong on the board.
+func (b *Board) ColumnOf(item *WorkItem) int {
+	if item.BoardColumn != "" {
+		for i, col := range b.Columns {
+			if col.Name == item.BoardColumn && col.Accepts(item.Type) {
+				return i
+			}
+		}
+	}
+	for i, col := range b.Columns {
+		if col.StateMappings[string(item.Type)] == string(item.State) {
+			return i
+		}
+	}
+	return -1
+}
+
+// RowOf returns the index of the swimlane an item is in, falling back to the default lane
+func (b *Board) RowOf(item *WorkItem) int {
+	def := 0
+	for i, row := range b.Rows {
+		if row.Name == item.BoardLane {
+			return i
+		}
+		if row.Name == "" {
+			def = i
+		}
+	}
+	return def
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ItemChangeKind is how a work item differs between two loads of a list
+type ItemChangeKind int
+
+const (
+	ItemAdded ItemChangeKind = iota + 1
+	ItemUpdated
+	ItemRemoved
+)
+
+// ItemChange is a work item that was added to, updated in or removed from a list
+// since it was last loaded
+type ItemChange struct {
+	Kind      ItemChangeKind
+	ChangedBy string
+	Fields    []string // What changed, e.g. "State: Active → Resolved", for updated items
+}
+
+// Summary describes the change in a line
+func (c ItemChange) Summary() string {
+	by := ""
+	if c.ChangedBy != "" {
+		by = " by " + c.ChangedBy
+	}
+	switch c.Kind {
+	case ItemAdded:
+		return "New in this list" + by
+	case ItemRemoved:
+		return "No longer matches the filters"
+	}
+	return "Changed" + by
+}
+
+// DiffWorkItems compares two loads of a list by ID and revision, keyed by work
+// item ID. Items in both loads at the same revision are left out.
+func DiffWorkItems(previous, current []WorkItem) map[int]ItemChange {
+	old := make(map[int]*WorkItem, len(previous))
+	for i := range previous {
+		old[previous[i].ID] = &previous[i]
+	}
+
+	changes := make(map[int]ItemChange)
+	for i := range current {
+		item := &current[i]
+		prev, ok := old[item.ID]
+		switch {
+		case !ok:
+			changes[item.ID] = ItemChange{Kind: ItemAdded, ChangedBy: item.ChangedBy}
+		case prev.Rev != item.Rev:
+			changes[item.ID] = ItemChange{Kind: ItemUpdated, ChangedBy: item.ChangedBy, Fields: changedFields(prev, item)}
+		}
+		delete(old, item.ID)
+	}
+	for id := range old {
+		changes[id] = ItemChange{Kind: ItemRemoved}
+	}
+	return changes
+}
+
+// changedFields describes the listed fields that differ between two revisions of
+// a work item
+func changedFields(prev, item *WorkItem) []string {
+	var fields []string
+	add := func(name, from, to string) {
+		if from == to {
+			return
+		}
+		if from == "" {
+			from = "-"
+		}
+		if to == "" {
+			to = "-"
+		}
+		fields = append(fields, fmt.Sprintf("%s: %s → %s", name, from, to))
+	}
+
+	add("Title", prev.Title, item.Title)
+	add("State", string(prev.State), string(item.State))
+	add("Assigned", prev.AssignedTo, item.AssignedTo)
+	add("Sprint", prev.SprintName(), item.SprintName())
+	add("Area", prev.AreaName(), item.AreaName())
+	add("Priority", fmt.Sprintf("%d", prev.Priority), fmt.Sprintf("%d", item.Priority))
+	add("Points", FormatPoints(prev.Points()), FormatPoints(item.Points()))
+	add("Remaining", FormatPoints(prev.RemainingWork), FormatPoints(item.RemainingWork))
+	add("Tags", strings.Join(prev.Tags, ", "), strings.Join(item.Tags, ", "))
+
+	if len(fields) == 0 {
+		// Fields the list doesn't show, comments or links
+		fields = append(fields, fmt.Sprintf("Revision %d → %d", prev.Rev, item.Rev))
+	}
+	return fields
+}
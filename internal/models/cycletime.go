@@ -0,0 +1,174 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ItemTiming is when a completed work item was created, started and completed
+type ItemTiming struct {
+	Item      WorkItem
+	Created   time.Time
+	Started   time.Time // Zero if the item never was in progress
+	Completed time.Time
+}
+
+// HasCycleTime reports whether the item was in progress before it was completed
+func (t ItemTiming) HasCycleTime() bool {
+	return !t.Started.IsZero()
+}
+
+// CycleTime returns the time from starting to completing the item
+func (t ItemTiming) CycleTime() time.Duration {
+	if !t.HasCycleTime() {
+		return 0
+	}
+	return t.Completed.Sub(t.Started)
+}
+
+// LeadTime returns the time from creating to completing the item
+func (t ItemTiming) LeadTime() time.Duration {
+	return t.Completed.Sub(t.Created)
+}
+
+// ItemTimings derives the timing of the items completed on or after since from their
+// state history, ordered by completion. An item is started when it first enters an
+// InProgress state, or a Resolved one if it skipped those, and completed when it last
+// entered a Completed state. Items that aren't completed now are left out.
+func ItemTimings(items []WorkItem, timelines map[int]FieldTimeline, statesByType map[string][]WorkItemStateInfo, since time.Time) []ItemTiming {
+	var timings []ItemTiming
+	for _, item := range items {
+		timeline, ok := timelines[item.ID]
+		if !ok {
+			continue
+		}
+
+		timing := ItemTiming{Item: item, Created: timeline.Created()}
+		if timing.Created.IsZero() {
+			timing.Created = item.CreatedDate
+		}
+
+		var resolved time.Time
+		completed := false
+		for _, c := range timeline {
+			if c.Field != "System.State" {
+				continue
+			}
+			switch StateCategoryOf(statesByType, item.Type, c.Value) {
+			case "InProgress":
+				if timing.Started.IsZero() {
+					timing.Started = c.Date
+				}
+				completed = false
+			case "Resolved":
+				if resolved.IsZero() {
+					resolved = c.Date
+				}
+				completed = false
+			case "Completed":
+				if !completed {
+					timing.Completed = c.Date
+				}
+				completed = true
+			default:
+				completed = false
+			}
+		}
+		if !completed || timing.Completed.Before(since) {
+			continue
+		}
+		if timing.Started.IsZero() && !resolved.IsZero() && resolved.Before(timing.Completed) {
+			timing.Started = resolved
+		}
+		timings = append(timings, timing)
+	}
+
+	sort.SliceStable(timings, func(i, j int) bool {
+		return timings[i].Completed.Before(timings[j].Completed)
+	})
+	return timings
+}
+
+// Percentiles are the 50th, 85th and 95th percentiles of some durations
+type Percentiles struct {
+	P50 time.Duration
+	P85 time.Duration
+	P95 time.Duration
+}
+
+// PercentilesOf returns the percentiles of the durations by the nearest rank
+// method, or zeros if there are none
+func PercentilesOf(durations []time.Duration) Percentiles {
+	if len(durations) == 0 {
+		return Percentiles{}
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := func(p float64) time.Duration {
+		n := int(math.Ceil(p / 100 * float64(len(sorted))))
+		if n < 1 {
+			n = 1
+		}
+		return sorted[n-1]
+	}
+	return Percentiles{P50: rank(50), P85: rank(85), P95: rank(95)}
+}
+
+// TimingStats are the cycle and lead time percentiles of the items of a type
+type TimingStats struct {
+	Type    WorkItemType // Empty for all types together
+	Items   int
+	Started int // Items with a cycle time
+	Cycle   Percentiles
+	Lead    Percentiles
+}
+
+// TimingStatsByType returns the stats of each work item type, sorted by name,
+// followed by those of all types together if there's more than one
+func TimingStatsByType(timings []ItemTiming) []TimingStats {
+	byType := make(map[WorkItemType][]ItemTiming)
+	var types []WorkItemType
+	for _, t := range timings {
+		if _, ok := byType[t.Item.Type]; !ok {
+			types = append(types, t.Item.Type)
+		}
+		byType[t.Item.Type] = append(byType[t.Item.Type], t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	var stats []TimingStats
+	for _, typ := range types {
+		s := timingStats(byType[typ])
+		s.Type = typ
+		stats = append(stats, s)
+	}
+	if len(types) > 1 {
+		stats = append(stats, timingStats(timings))
+	}
+	return stats
+}
+
+func timingStats(timings []ItemTiming) TimingStats {
+	var cycle, lead []time.Duration
+	for _, t := range timings {
+		if t.HasCycleTime() {
+			cycle = append(cycle, t.CycleTime())
+		}
+		lead = append(lead, t.LeadTime())
+	}
+	return TimingStats{
+		Items:   len(timings),
+		Started: len(cycle),
+		Cycle:   PercentilesOf(cycle),
+		Lead:    PercentilesOf(lead),
+	}
+}
+
+// FormatDays formats a duration in days with one decimal, e.g. "3.5d"
+func FormatDays(d time.Duration) string {
+	return strconv.FormatFloat(d.Hours()/24, 'f', 1, 64) + "d"
+}
@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// DevelopmentLinkKind is the kind of Git artifact linked to a work item
+type DevelopmentLinkKind string
+
+const (
+	DevelopmentPullRequest DevelopmentLinkKind = "PullRequest"
+	DevelopmentCommit      DevelopmentLinkKind = "Commit"
+	DevelopmentBranch      DevelopmentLinkKind = "Branch"
+)
+
+// Reviewer is a pull request reviewer and their vote
+type Reviewer struct {
+	Name string `json:"name"`
+	Vote int    `json:"vote"` // 10 approved, 5 approved with suggestions, 0 none, -5 waiting, -10 rejected
+}
+
+// VoteLabel returns a short description of the reviewer's vote
+func (r Reviewer) VoteLabel() string {
+	switch {
+	case r.Vote >= 10:
+		return "approved"
+	case r.Vote > 0:
+		return "approved with suggestions"
+	case r.Vote <= -10:
+		return "rejected"
+	case r.Vote < 0:
+		return "waiting for author"
+	}
+	return "no vote"
+}
+
+// DevelopmentLink is a pull request, commit or branch linked to a work item.
+// Only the kind, repository ID and Ref are known until details are fetched.
+type DevelopmentLink struct {
+	Kind         DevelopmentLinkKind `json:"kind"`
+	ProjectID    string              `json:"projectId"`
+	RepositoryID string              `json:"repositoryId"`
+	Ref          string              `json:"ref"` // Pull request ID, commit SHA or branch name
+	Repository   string              `json:"repository"`
+	Title        string              `json:"title"`  // PR title or first line of the commit message
+	Status       string              `json:"status"` // PR status: active, completed, abandoned
+	MergeStatus  string              `json:"mergeStatus"`
+	IsDraft      bool                `json:"isDraft"`
+	Reviewers    []Reviewer          `json:"reviewers"`
+	Author       string              `json:"author"`
+	Date         time.Time           `json:"date"`
+	WebURL       string              `json:"webUrl"`
+}
+
+// ShortRef returns the reference as displayed, e.g. "!42", a short SHA or the branch name
+func (l *DevelopmentLink) ShortRef() string {
+	switch l.Kind {
+	case DevelopmentPullRequest:
+		return "!" + l.Ref
+	case DevelopmentCommit:
+		if len(l.Ref) > 8 {
+			return l.Ref[:8]
+		}
+	}
+	return l.Ref
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FieldType is the data type of a work item field
+type FieldType string
+
+const (
+	FieldTypeString    FieldType = "string"
+	FieldTypeInteger   FieldType = "integer"
+	FieldTypeDouble    FieldType = "double"
+	FieldTypeBoolean   FieldType = "boolean"
+	FieldTypeDateTime  FieldType = "dateTime"
+	FieldTypePlainText FieldType = "plainText"
+	FieldTypeHTML      FieldType = "html"
+	FieldTypeTreePath  FieldType = "treePath"
+	FieldTypeIdentity  FieldType = "identity"
+)
+
+// maxStringFieldLength is the server-side limit for single-line string fields
+const maxStringFieldLength = 255
+
+// FieldDefinition describes a field as defined for a work item type
+type FieldDefinition struct {
+	ReferenceName string    `json:"referenceName"`
+	Name          string    `json:"name"`
+	Type          FieldType `json:"type"`
+	Required      bool      `json:"required"`
+	AllowedValues []string  `json:"allowedValues"`
+}
+
+// knownFieldTypes is used when the server doesn't report a field's type
+var knownFieldTypes = map[string]FieldType{
+	"System.Title":                               FieldTypeString,
+	"System.Tags":                                FieldTypePlainText,
+	"System.Description":                         FieldTypeHTML,
+	"System.IterationPath":                       FieldTypeTreePath,
+	"System.AreaPath":                            FieldTypeTreePath,
+	"Microsoft.VSTS.Common.Priority":             FieldTypeInteger,
+	"Microsoft.VSTS.Common.Severity":             FieldTypeString,
+	"Microsoft.VSTS.Common.Activity":             FieldTypeString,
+	"Microsoft.VSTS.Common.ValueArea":            FieldTypeString,
+	"Microsoft.VSTS.Common.AcceptanceCriteria":   FieldTypeHTML,
+	"Microsoft.VSTS.TCM.ReproSteps":              FieldTypeHTML,
+	"Microsoft.VSTS.Scheduling.StoryPoints":      FieldTypeDouble,
+	"Microsoft.VSTS.Scheduling.Effort":           FieldTypeDouble,
+	"Microsoft.VSTS.Scheduling.RemainingWork":    FieldTypeDouble,
+	"Microsoft.VSTS.Scheduling.CompletedWork":    FieldTypeDouble,
+	"Microsoft.VSTS.Scheduling.OriginalEstimate": FieldTypeDouble,
+}
+
+// KnownFieldType returns the built-in type of a common field, defaulting to string
+func KnownFieldType(referenceName string) FieldType {
+	if t, ok := knownFieldTypes[referenceName]; ok {
+		return t
+	}
+	return FieldTypeString
+}
+
+// Parse validates user input against the field definition and converts it to the
+// value sent to the server. An empty input clears optional fields (nil value).
+func (f FieldDefinition) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+
+	if input == "" {
+		if f.Required {
+			return nil, fmt.Errorf("%s is required", f.Name)
+		}
+		return nil, nil
+	}
+
+	if len(f.AllowedValues) > 0 {
+		for _, allowed := range f.AllowedValues {
+			if strings.EqualFold(allowed, input) {
+				return f.convert(allowed)
+			}
+		}
+		return nil, fmt.Errorf("%s must be one of: %s", f.Name, strings.Join(f.AllowedValues, ", "))
+	}
+
+	return f.convert(input)
+}
+
+// convert converts a non-empty input to the field's type
+func (f FieldDefinition) convert(input string) (interface{}, error) {
+	switch f.Type {
+	case FieldTypeInteger:
+		n, err := strconv.Atoi(input)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a whole number", f.Name)
+		}
+		return n, nil
+	case FieldTypeDouble:
+		n, err := strconv.ParseFloat(input, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number", f.Name)
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("%s cannot be negative", f.Name)
+		}
+		return n, nil
+	case FieldTypeBoolean:
+		b, err := strconv.ParseBool(input)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false", f.Name)
+		}
+		return b, nil
+	case FieldTypeString:
+		if len(input) > maxStringFieldLength {
+			return nil, fmt.Errorf("%s is limited to %d characters", f.Name, maxStringFieldLength)
+		}
+	}
+	return input, nil
+}
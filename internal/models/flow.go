@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// FlowDay is the number of items in each state category at the end of a day
+type FlowDay struct {
+	Date   time.Time
+	Counts map[string]int // By state category, see TaskboardCategories
+}
+
+// Total returns the number of items counted on the day
+func (d FlowDay) Total() int {
+	total := 0
+	for _, n := range d.Counts {
+		total += n
+	}
+	return total
+}
+
+// CumulativeFlow counts the items per state category at the end of every day from
+// start to end. Items with a timeline are placed by their state history; the others
+// are taken to have had their current state since they were created. Items that
+// were already completed at the start are left out.
+func CumulativeFlow(items []WorkItem, timelines map[int]FieldTimeline, statesByType map[string][]WorkItemStateInfo, start, end time.Time) []FlowDay {
+	startDay, endDay := truncateDay(start), truncateDay(end)
+
+	stateAt := func(item *WorkItem, at time.Time) string {
+		if t, ok := timelines[item.ID]; ok {
+			return t.ValueAt("System.State", at)
+		}
+		if item.CreatedDate.After(at) {
+			return ""
+		}
+		return string(item.State)
+	}
+
+	// Leave out what was finished before the range
+	var counted []*WorkItem
+	for i := range items {
+		item := &items[i]
+		if StateCategoryOf(statesByType, item.Type, stateAt(item, startDay)) != "Completed" {
+			counted = append(counted, item)
+		}
+	}
+
+	var days []FlowDay
+	for day := startDay; !day.After(endDay); day = day.AddDate(0, 0, 1) {
+		eod := day.AddDate(0, 0, 1).Add(-time.Second)
+		counts := make(map[string]int, len(TaskboardCategories))
+		for _, item := range counted {
+			state := stateAt(item, eod)
+			if state == "" {
+				continue
+			}
+			switch category := StateCategoryOf(statesByType, item.Type, state); category {
+			case "", "Removed":
+			default:
+				counts[category]++
+			}
+		}
+		days = append(days, FlowDay{Date: day, Counts: counts})
+	}
+	return days
+}
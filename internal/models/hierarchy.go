@@ -0,0 +1,78 @@
+package models
+
+// HierarchyLink is a parent-child link between two work items
+type HierarchyLink struct {
+	ParentID int // 0 for a top-level item
+	ChildID  int
+}
+
+// HierarchyNode is a work item and its children in a backlog tree
+type HierarchyNode struct {
+	Item     WorkItem
+	Children []*HierarchyNode
+}
+
+// HierarchyRollup sums up the descendants of a tree node
+type HierarchyRollup struct {
+	Children      int     // Direct children
+	Descendants   int     // Children, grandchildren and so on
+	Points        float64 // Story points or effort of the descendants
+	RemainingWork float64 // Remaining work of the descendants, in hours
+}
+
+// BuildHierarchy arranges items into trees following the links, keeping the order
+// of the items among siblings. Items whose parent isn't among the items become roots.
+func BuildHierarchy(items []WorkItem, links []HierarchyLink) []*HierarchyNode {
+	nodes := make(map[int]*HierarchyNode, len(items))
+	for _, item := range items {
+		nodes[item.ID] = &HierarchyNode{Item: item}
+	}
+
+	parents := make(map[int]int, len(links))
+	for _, link := range links {
+		if link.ParentID == 0 || nodes[link.ParentID] == nil || link.ParentID == link.ChildID {
+			continue
+		}
+		if _, ok := parents[link.ChildID]; !ok {
+			parents[link.ChildID] = link.ParentID
+		}
+	}
+
+	var roots []*HierarchyNode
+	for _, item := range items {
+		node := nodes[item.ID]
+		if parent, ok := parents[item.ID]; ok && !isAncestor(parents, item.ID, parent) {
+			nodes[parent].Children = append(nodes[parent].Children, node)
+			continue
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
+
+// isAncestor reports whether id is an ancestor of parent, which would make a cycle
+func isAncestor(parents map[int]int, id, parent int) bool {
+	for steps := 0; steps <= len(parents); steps++ {
+		if parent == id {
+			return true
+		}
+		next, ok := parents[parent]
+		if !ok {
+			return false
+		}
+		parent = next
+	}
+	return true
+}
+
+// Rollup sums up the node's descendants
+func (n *HierarchyNode) Rollup() HierarchyRollup {
+	r := HierarchyRollup{Children: len(n.Children)}
+	for _, child := range n.Children {
+		sub := child.Rollup()
+		r.Descendants += 1 + sub.Descendants
+		r.Points += child.Item.Points() + sub.Points
+		r.RemainingWork += child.Item.RemainingWork + sub.RemainingWork
+	}
+	return r
+}
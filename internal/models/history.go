@@ -0,0 +1,79 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// WorkItemRevision is one update of a work item's history
+type WorkItemRevision struct {
+	Rev         int          `json:"rev"`
+	RevisedBy   string       `json:"revisedBy"`
+	RevisedDate time.Time    `json:"revisedDate"`
+	Fields      []FieldDelta `json:"fields"`
+	Links       []LinkDelta  `json:"links"`
+}
+
+// FieldDelta is the change of a single field in a revision
+type FieldDelta struct {
+	Field    string `json:"field"`    // Reference name, e.g. "System.State"
+	OldValue string `json:"oldValue"` // Empty if the field was not set
+	NewValue string `json:"newValue"` // Empty if the field was cleared
+	LongText bool   `json:"longText"` // Rich text converted to plain text
+}
+
+// LinkDelta is a relation added or removed in a revision
+type LinkDelta struct {
+	Added       bool   `json:"added"`
+	LinkType    string `json:"linkType"`    // e.g. "Parent", "Related", "Attachment"
+	Description string `json:"description"` // e.g. "#123" or a file name
+}
+
+// Label returns a readable name for the field, e.g. "Acceptance Criteria"
+// for "Microsoft.VSTS.Common.AcceptanceCriteria"
+func (f FieldDelta) Label() string {
+	if f.Field == "System.History" {
+		return "Comment"
+	}
+	return FieldLabel(f.Field)
+}
+
+// FieldLabel derives a readable name from a field reference name by splitting
+// its last segment on case changes
+func FieldLabel(referenceName string) string {
+	name := referenceName
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// Split "StoryPoints" and the end of acronyms like "URLField"
+			if unicode.IsLower(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteRune(' ')
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ChangeCount returns the number of field and link changes in the revision
+func (r *WorkItemRevision) ChangeCount() int {
+	return len(r.Fields) + len(r.Links)
+}
+
+// FieldChange returns the change of a field in the revision, if it changed
+func (r *WorkItemRevision) FieldChange(field string) (FieldDelta, bool) {
+	for _, f := range r.Fields {
+		if f.Field == field {
+			return f, true
+		}
+	}
+	return FieldDelta{}, false
+}
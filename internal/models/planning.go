@@ -0,0 +1,50 @@
+package models
+
+// Points returns the size of a backlog item used in sprint planning: story points,
+// or effort in process templates that size items by effort
+func (w *WorkItem) Points() float64 {
+	if w.StoryPoints > 0 {
+		return w.StoryPoints
+	}
+	return w.Effort
+}
+
+// FormatPoints formats a number of points, e.g. "13" or "2.5"
+func FormatPoints(points float64) string {
+	return formatFloat(points)
+}
+
+// DateRange returns the iteration's dates, e.g. "3 Mar - 14 Mar", or "" if unscheduled
+func (i *Iteration) DateRange() string {
+	if i.StartDate.IsZero() || i.FinishDate.IsZero() {
+		return ""
+	}
+	return i.StartDate.Format("2 Jan") + " - " + i.FinishDate.Format("2 Jan")
+}
+
+// StateCategory returns the category of the item's state, e.g. "InProgress" or
+// "Completed". Falls back to the default process state names if the state isn't
+// in statesByType, and returns "" if it's unknown.
+func (w *WorkItem) StateCategory(statesByType map[string][]WorkItemStateInfo) string {
+	return StateCategoryOf(statesByType, w.Type, string(w.State))
+}
+
+// StateCategoryOf is like WorkItem.StateCategory for any state of a work item type,
+// such as one from its history
+func StateCategoryOf(statesByType map[string][]WorkItemStateInfo, workItemType WorkItemType, state string) string {
+	for _, s := range statesByType[string(workItemType)] {
+		if s.Name == state {
+			return s.Category
+		}
+	}
+	return defaultStateCategories[state]
+}
+
+// IsClosed reports whether the item is done or removed
+func (w *WorkItem) IsClosed(statesByType map[string][]WorkItemStateInfo) bool {
+	switch w.StateCategory(statesByType) {
+	case "Completed", "Removed":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// DateRange is a range of whole days, both ends included
+type DateRange struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Contains returns whether the day of t is within the range
+func (d DateRange) Contains(t time.Time) bool {
+	day := truncateDay(t)
+	return !day.Before(truncateDay(d.Start)) && !day.After(truncateDay(d.End))
+}
+
+// MemberCapacity is the capacity a team member has planned for a sprint
+type MemberCapacity struct {
+	Member         TeamMember
+	CapacityPerDay float64 // Hours, summed over activities
+	DaysOff        []DateRange
+}
+
+// TeamCapacity is the planned capacity of the team for a sprint
+type TeamCapacity struct {
+	Members     []MemberCapacity
+	TeamDaysOff []DateRange
+	WorkingDays []time.Weekday
+}
+
+// SprintDays returns the working days of an iteration that aren't team days off
+func (c *TeamCapacity) SprintDays(it *Iteration) []time.Time {
+	if it.StartDate.IsZero() || it.FinishDate.IsZero() {
+		return nil
+	}
+
+	var days []time.Time
+	for day := truncateDay(it.StartDate); !day.After(truncateDay(it.FinishDate)); day = day.AddDate(0, 0, 1) {
+		if c.isWorkingDay(day) && !inRanges(c.TeamDaysOff, day) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// isWorkingDay returns whether the team works on the weekday of day
+func (c *TeamCapacity) isWorkingDay(day time.Time) bool {
+	if len(c.WorkingDays) == 0 {
+		return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+	}
+	for _, wd := range c.WorkingDays {
+		if day.Weekday() == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// Hours returns the capacity of a member over the given sprint days, leaving out
+// their days off
+func (m *MemberCapacity) Hours(days []time.Time) float64 {
+	var hours float64
+	for _, day := range days {
+		if !inRanges(m.DaysOff, day) {
+			hours += m.CapacityPerDay
+		}
+	}
+	return hours
+}
+
+// DaysOffIn returns how many of the given sprint days the member is off
+func (m *MemberCapacity) DaysOffIn(days []time.Time) int {
+	n := 0
+	for _, day := range days {
+		if inRanges(m.DaysOff, day) {
+			n++
+		}
+	}
+	return n
+}
+
+// Find returns the capacity of the member with the given display name, or nil
+func (c *TeamCapacity) Find(displayName string) *MemberCapacity {
+	for i := range c.Members {
+		if strings.EqualFold(c.Members[i].Member.DisplayName, displayName) {
+			return &c.Members[i]
+		}
+	}
+	return nil
+}
+
+// BurndownPoint is the remaining work of a sprint at the end of a day
+type BurndownPoint struct {
+	Date      time.Time
+	Remaining float64
+}
+
+// Burndown sums the remaining work of the items that were in the iteration at the
+// end of each of the given days, up to and including today
+func Burndown(timelines map[int]FieldTimeline, iterationPath string, days []time.Time, now time.Time) []BurndownPoint {
+	var points []BurndownPoint
+	for _, day := range days {
+		if day.After(now) {
+			break
+		}
+		end := day.AddDate(0, 0, 1).Add(-time.Second)
+		var remaining float64
+		for _, t := range timelines {
+			if t.ValueAt("System.IterationPath", end) == iterationPath {
+				remaining += t.FloatAt("Microsoft.VSTS.Scheduling.RemainingWork", end)
+			}
+		}
+		points = append(points, BurndownPoint{Date: day, Remaining: remaining})
+	}
+	return points
+}
+
+// inRanges returns whether day is in any of the ranges
+func inRanges(ranges []DateRange, day time.Time) bool {
+	for _, r := range ranges {
+		if r.Contains(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateDay returns midnight UTC of t's date; sprint dates are UTC midnights
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,66 @@
+package models
+
+import "sort"
+
+// TaskboardCategories are the state categories shown as taskboard columns, in
+// workflow order
+var TaskboardCategories = []string{"Proposed", "InProgress", "Resolved", "Completed"}
+
+// defaultStateCategories maps the states of the default processes (Agile, Scrum,
+// Basic, CMMI) to their categories, for when state metadata isn't available
+var defaultStateCategories = map[string]string{
+	"New":         "Proposed",
+	"To Do":       "Proposed",
+	"Proposed":    "Proposed",
+	"Approved":    "Proposed",
+	"Active":      "InProgress",
+	"Doing":       "InProgress",
+	"In Progress": "InProgress",
+	"Committed":   "InProgress",
+	"Resolved":    "Resolved",
+	"Closed":      "Completed",
+	"Done":        "Completed",
+	"Removed":     "Removed",
+}
+
+// CategoryLabel returns how a state category is shown, e.g. "In Progress"
+func CategoryLabel(category string) string {
+	switch category {
+	case "Proposed":
+		return "To Do"
+	case "InProgress":
+		return "In Progress"
+	case "Completed":
+		return "Done"
+	}
+	return category
+}
+
+// StatesInCategory returns the states of a work item type in a category, in
+// workflow order
+func StatesInCategory(statesByType map[string][]WorkItemStateInfo, workItemType WorkItemType, category string) []string {
+	var states []string
+	for _, s := range statesByType[string(workItemType)] {
+		if s.Category == category {
+			states = append(states, s.Name)
+		}
+	}
+	return states
+}
+
+// AllStatesInCategory returns the distinct states of any work item type in a
+// category, sorted
+func AllStatesInCategory(statesByType map[string][]WorkItemStateInfo, category string) []string {
+	seen := make(map[string]bool)
+	var states []string
+	for _, typeStates := range statesByType {
+		for _, s := range typeStates {
+			if s.Category == category && !seen[s.Name] {
+				seen[s.Name] = true
+				states = append(states, s.Name)
+			}
+		}
+	}
+	sort.Strings(states)
+	return states
+}
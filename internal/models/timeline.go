@@ -0,0 +1,45 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// FieldEvent is a field of a work item taking a new value
+type FieldEvent struct {
+	Date  time.Time
+	Field string // Reference name, e.g. "System.State"
+	Value string // Empty if the field was cleared
+}
+
+// FieldTimeline is the history of some fields of a work item, oldest first
+type FieldTimeline []FieldEvent
+
+// Created returns when the work item was created, the date of its first change
+func (t FieldTimeline) Created() time.Time {
+	if len(t) == 0 {
+		return time.Time{}
+	}
+	return t[0].Date
+}
+
+// ValueAt returns the value a field had at the given time, or "" if the item
+// didn't exist yet or the field wasn't set
+func (t FieldTimeline) ValueAt(field string, at time.Time) string {
+	value := ""
+	for _, c := range t {
+		if c.Date.After(at) {
+			break
+		}
+		if c.Field == field {
+			value = c.Value
+		}
+	}
+	return value
+}
+
+// FloatAt is like ValueAt for numeric fields, returning 0 if unset
+func (t FieldTimeline) FloatAt(field string, at time.Time) float64 {
+	f, _ := strconv.ParseFloat(t.ValueAt(field, at), 64)
+	return f
+}
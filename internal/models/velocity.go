@@ -0,0 +1,74 @@
+package models
+
+import "sort"
+
+// SprintVelocity is the work completed and left over in a past sprint
+type SprintVelocity struct {
+	Iteration  Iteration
+	Completed  float64 // Points of items in a Completed state
+	Incomplete float64 // Points of the other items
+	Items      int     // Completed items
+}
+
+// Velocity sums the points of the items in each iteration, oldest iteration first.
+// The items' current iteration and state are used, so an item counts towards the
+// sprint it was finished in.
+func Velocity(iterations []Iteration, items []WorkItem, statesByType map[string][]WorkItemStateInfo) []SprintVelocity {
+	sorted := make([]Iteration, len(iterations))
+	copy(sorted, iterations)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].FinishDate.Before(sorted[j].FinishDate)
+	})
+
+	index := make(map[string]int, len(sorted))
+	velocity := make([]SprintVelocity, len(sorted))
+	for i, it := range sorted {
+		index[it.Path] = i
+		velocity[i].Iteration = it
+	}
+
+	for i := range items {
+		item := &items[i]
+		n, ok := index[item.IterationPath]
+		if !ok {
+			continue
+		}
+		if item.StateCategory(statesByType) == "Completed" {
+			velocity[n].Completed += item.Points()
+			velocity[n].Items++
+		} else {
+			velocity[n].Incomplete += item.Points()
+		}
+	}
+	return velocity
+}
+
+// AverageVelocity returns the mean completed points per sprint
+func AverageVelocity(velocity []SprintVelocity) float64 {
+	if len(velocity) == 0 {
+		return 0
+	}
+	var total float64
+	for _, v := range velocity {
+		total += v.Completed
+	}
+	return total / float64(len(velocity))
+}
+
+// VelocityTrend returns the change in completed points per sprint, the slope of
+// the least squares line through the sprints
+func VelocityTrend(velocity []SprintVelocity) float64 {
+	n := float64(len(velocity))
+	if n < 2 {
+		return 0
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	for i, v := range velocity {
+		x := float64(i)
+		sumX += x
+		sumY += v.Completed
+		sumXY += x * v.Completed
+		sumXX += x * x
+	}
+	return (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+}
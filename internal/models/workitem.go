@@ -2,6 +2,8 @@ package models
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -26,11 +28,16 @@ const (
 	WorkItemStateClosed   WorkItemState = "Closed"
 )
 
+// CommentFormatMarkdown is the format of comments written in markdown
+const CommentFormatMarkdown = "markdown"
+
 // Comment represents a work item comment
 type Comment struct {
 	ID           int       `json:"id"`
-	Text         string    `json:"text"`
+	Text         string    `json:"text"`   // Markdown source, or plain text for HTML comments
+	Format       string    `json:"format"` // "markdown" or "html"
 	CreatedBy    string    `json:"createdBy"`
+	CreatedByID  string    `json:"createdById"`
 	CreatedDate  time.Time `json:"createdDate"`
 	ModifiedBy   string    `json:"modifiedBy"`
 	ModifiedDate time.Time `json:"modifiedDate"`
@@ -46,6 +53,32 @@ type RelatedLink struct {
 	URL      string `json:"url"`
 }
 
+// Attachment represents a file attached to a work item
+type Attachment struct {
+	Name       string    `json:"name"`
+	Size       int64     `json:"size"` // Bytes
+	URL        string    `json:"url"`
+	Comment    string    `json:"comment"`
+	AddedDate  time.Time `json:"addedDate"`
+	UploadedBy string    `json:"uploadedBy"` // Empty if unknown
+}
+
+// FormattedSize returns the size in human-readable units, e.g. "1.4 MB"
+func (a *Attachment) FormattedSize() string {
+	const unit = 1024
+	if a.Size < unit {
+		return fmt.Sprintf("%d B", a.Size)
+	}
+	size := float64(a.Size) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if size < unit || suffix == "GB" {
+			return fmt.Sprintf("%.1f %s", size, suffix)
+		}
+		size /= unit
+	}
+	return ""
+}
+
 // WorkItem represents an Azure DevOps work item
 type WorkItem struct {
 	ID            int           `json:"id"`
@@ -84,12 +117,17 @@ type WorkItem struct {
 	Reason             string  `json:"reason"`             // State change reason
 	BoardColumn        string  `json:"boardColumn"`        // Current board column
 	BoardColumnDone    bool    `json:"boardColumnDone"`    // Is in done sub-column
+	BoardLane          string  `json:"boardLane"`          // Swimlane, empty for the default lane
 	CommentCount       int     `json:"commentCount"`       // Number of comments
 
 	// Relations
 	Comments     []Comment     `json:"comments"`
 	RelatedLinks []RelatedLink `json:"relatedLinks"`
 	ChildIDs     []int         `json:"childIds"`
+	Attachments  []Attachment  `json:"attachments"`
+
+	// Pull requests, commits and branches
+	DevelopmentLinks []DevelopmentLink `json:"developmentLinks"`
 }
 
 // ShortType returns a short version of the work item type
@@ -182,6 +220,65 @@ func (w *WorkItem) GetEstimateValue() string {
 	return ""
 }
 
+// FieldValue returns the display value of a field by its reference name,
+// or an empty string if the field is not tracked on the model
+func (w *WorkItem) FieldValue(field string) string {
+	switch field {
+	case "System.Title":
+		return w.Title
+	case "System.State":
+		return string(w.State)
+	case "System.Reason":
+		return w.Reason
+	case "System.AssignedTo":
+		return w.AssignedTo
+	case "System.IterationPath":
+		return w.IterationPath
+	case "System.AreaPath":
+		return w.AreaPath
+	case "System.Description":
+		return w.Description
+	case "System.Tags":
+		return strings.Join(w.Tags, "; ")
+	case "System.BoardColumn":
+		return w.BoardColumn
+	case "System.BoardColumnDone":
+		return strconv.FormatBool(w.BoardColumnDone)
+	case "Microsoft.VSTS.Common.Priority":
+		return fmt.Sprintf("%d", w.Priority)
+	case "Microsoft.VSTS.Common.AcceptanceCriteria":
+		return w.AcceptanceCriteria
+	case "Microsoft.VSTS.TCM.ReproSteps":
+		return w.ReproSteps
+	case "Microsoft.VSTS.Scheduling.StoryPoints":
+		return formatFloat(w.StoryPoints)
+	case "Microsoft.VSTS.Scheduling.Effort":
+		return formatFloat(w.Effort)
+	case "Microsoft.VSTS.Scheduling.RemainingWork":
+		return formatFloat(w.RemainingWork)
+	case "Microsoft.VSTS.Scheduling.CompletedWork":
+		return formatFloat(w.CompletedWork)
+	case "Microsoft.VSTS.Scheduling.OriginalEstimate":
+		return formatFloat(w.OriginalEstimate)
+	case "Microsoft.VSTS.Common.Activity":
+		return w.Activity
+	case "Microsoft.VSTS.Common.Severity":
+		return w.Severity
+	case "Microsoft.VSTS.Common.ValueArea":
+		return w.ValueArea
+	case "Microsoft.VSTS.Common.Risk":
+		return w.Risk
+	}
+	// A board's own column fields mirror System.BoardColumn and BoardColumnDone
+	switch {
+	case strings.HasSuffix(field, "_Kanban.Column"):
+		return w.BoardColumn
+	case strings.HasSuffix(field, "_Kanban.Column.Done"):
+		return strconv.FormatBool(w.BoardColumnDone)
+	}
+	return ""
+}
+
 // formatFloat formats a float nicely (removes trailing zeros)
 func formatFloat(f float64) string {
 	if f == float64(int(f)) {
@@ -1,19 +1,29 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/samuelenocsson/devops-tui/internal/api"
+	"github.com/samuelenocsson/devops-tui/internal/cache"
 	"github.com/samuelenocsson/devops-tui/internal/config"
 	"github.com/samuelenocsson/devops-tui/internal/models"
 	"github.com/samuelenocsson/devops-tui/internal/ui/components"
 	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
 	"github.com/samuelenocsson/devops-tui/pkg/browser"
 	"github.com/samuelenocsson/devops-tui/pkg/git"
+	"github.com/samuelenocsson/devops-tui/pkg/markup"
 )
 
 // Panel represents the active panel
@@ -31,8 +41,19 @@ type ViewMode int
 const (
 	ViewMain ViewMode = iota
 	ViewDetail
+	ViewHistory
+	ViewPlanning
+	ViewBoard
+	ViewTaskboard
+	ViewSprint
+	ViewVelocity
+	ViewFlow
+	ViewCycleTime
 )
 
+// offlineNotice is shown when a change is refused while offline
+const offlineNotice = "Offline - read-only until Azure DevOps can be reached (Ctrl+r to retry)"
+
 // App is the main application model
 type App struct {
 	// Components
@@ -40,10 +61,27 @@ type App struct {
 	workItemsPanel components.WorkItemsPanel
 	detailsPanel   components.DetailsPanel
 	detailView     *components.DetailView
+	historyView    *components.HistoryView
+	planningView   *components.PlanningView
+	boardView      *components.BoardView
+	taskboardView  *components.TaskboardView
+	sprintView     *components.SprintView
+	velocityView   *components.VelocityView
+	flowView       *components.FlowView
+	cycleTimeView  *components.CycleTimeView
 	helpPanel      components.HelpPanel
 	stateModal     components.StateModal
 	branchModal    components.BranchModal
 	assignModal    components.AssignModal
+	conflictModal  components.ConflictModal
+	fieldEditor    components.FieldEditor
+	createModal    components.CreateModal
+	commentEditor  components.CommentEditor
+	linkModal      components.LinkModal
+	attachModal    components.AttachmentModal
+	bulkModal      components.BulkModal
+	sprintModal    components.SprintModal
+	wiqlEditor     components.WIQLEditor
 
 	// State
 	activePanel Panel
@@ -52,15 +90,53 @@ type App struct {
 	err         error
 	statusMsg   string // Temporary status message
 
+	// In-flight loads; superseded ones are cancelled and their results dropped
+	loadCancel     context.CancelFunc
+	loadSeq        int
+	detailCancel   context.CancelFunc
+	historyCancel  context.CancelFunc
+	planningCancel context.CancelFunc
+	sprintCancel   context.CancelFunc
+	flowCancel     context.CancelFunc
+	cycleCancel    context.CancelFunc
+	velocityCancel context.CancelFunc
+	attachCancel   context.CancelFunc // Attachment transfer, cancelled when its modal is closed
+	boardCancel    context.CancelFunc
+
+	// Requests of the board, taskboard or planning view shown, cancelled when it closes
+	viewCtx    context.Context
+	viewCancel context.CancelFunc
+
+	// Raw WIQL query the list shows instead of the filters' results, empty if none
+	wiqlQuery string
+
+	// View to return to when the history view is closed
+	historyReturn ViewMode
+
+	// Bulk action in progress, nil if none
+	bulk *bulkRun
+
+	// Completion message of an update waiting on conflict resolution
+	pendingDone tea.Msg
+
 	// Data
 	iterations   []models.Iteration
 	areas        []models.Area
 	workItems    []models.WorkItem
 	statesByType map[string][]models.WorkItemStateInfo
 	teamMembers  []models.TeamMember
+	fieldDefs    map[string][]models.FieldDefinition // Editable field definitions by work item type
+
+	// Offline cache; cached results are shown until the server answers, and
+	// instead of it while offline
+	cache          *cache.Store
+	offline        bool      // The last request couldn't reach Azure DevOps
+	staleSince     time.Time // When the listed work items were synced, zero if just now
+	metadataLoaded bool      // Project data came from the server rather than the cache
 
 	// Services
 	client *api.Client
+	cfg    *config.Config
 
 	// Config
 	styles theme.Styles
@@ -72,7 +148,7 @@ type App struct {
 }
 
 // NewApp creates a new application
-func NewApp(client *api.Client) App {
+func NewApp(client *api.Client, cfg *config.Config) App {
 	styles := theme.DefaultStyles()
 	keys := theme.DefaultKeyMap()
 
@@ -81,20 +157,48 @@ func NewApp(client *api.Client) App {
 
 	// Initialize detailView separately to get pointer
 	detailView := components.NewDetailView(styles, keys)
+	historyView := components.NewHistoryView(styles, keys)
+	planningView := components.NewPlanningView(styles, keys)
+	boardView := components.NewBoardView(styles, keys)
+	taskboardView := components.NewTaskboardView(styles, keys)
+	sprintView := components.NewSprintView(styles, keys)
+	velocityView := components.NewVelocityView(styles, keys)
+	flowView := components.NewFlowView(styles, keys)
+	cycleTimeView := components.NewCycleTimeView(styles, keys)
 
 	return App{
 		filterPanel:    components.NewFilterPanel(filterState, styles, keys),
 		workItemsPanel: components.NewWorkItemsPanel(styles, keys),
 		detailsPanel:   components.NewDetailsPanel(styles, keys),
 		detailView:     &detailView,
+		historyView:    &historyView,
+		planningView:   &planningView,
+		boardView:      &boardView,
+		taskboardView:  &taskboardView,
+		sprintView:     &sprintView,
+		velocityView:   &velocityView,
+		flowView:       &flowView,
+		cycleTimeView:  &cycleTimeView,
 		helpPanel:      components.NewHelpPanel(keys, styles),
 		stateModal:     components.NewStateModal(styles, keys),
 		branchModal:    components.NewBranchModal(styles, keys),
 		assignModal:    components.NewAssignModal(styles, keys),
+		conflictModal:  components.NewConflictModal(styles, keys),
+		fieldEditor:    components.NewFieldEditor(styles, keys),
+		createModal:    components.NewCreateModal(styles, keys),
+		commentEditor:  components.NewCommentEditor(styles, keys),
+		linkModal:      components.NewLinkModal(styles, keys),
+		attachModal:    components.NewAttachmentModal(styles, keys),
+		bulkModal:      components.NewBulkModal(styles, keys),
+		sprintModal:    components.NewSprintModal(styles, keys),
+		wiqlEditor:     components.NewWIQLEditor(styles, keys),
+		fieldDefs:      make(map[string][]models.FieldDefinition),
 		activePanel:    PanelWorkItems,
 		viewMode:       ViewMain,
 		loading:        true,
+		cache:          cache.Open(config.GetConfigDir(), client.CollectionURL(), client.Project(), client.Team()),
 		client:         client,
+		cfg:            cfg,
 		styles:         styles,
 		keys:           keys,
 	}
@@ -103,7 +207,9 @@ func NewApp(client *api.Client) App {
 // Init initializes the application
 func (a App) Init() tea.Cmd {
 	return tea.Batch(
-		loadDataCmd(a.client),
+		loadCacheCmd(a.cache),
+		loadDataCmd(a.client, a.cache),
+		refreshTickCmd(a.cfg.RefreshInterval),
 	)
 }
 
@@ -111,6 +217,11 @@ func (a App) Init() tea.Cmd {
 func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// Nothing can be changed while offline, whichever view asks for it
+	if a.offline && a.refuseOffline(msg) {
+		return a, nil
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
@@ -146,6 +257,78 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, tea.Batch(cmds...)
 		}
 
+		if a.conflictModal.IsVisible() {
+			newModal, cmd := a.conflictModal.Update(msg)
+			a.conflictModal = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.fieldEditor.IsVisible() {
+			newModal, cmd := a.fieldEditor.Update(msg)
+			a.fieldEditor = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.createModal.IsVisible() {
+			newModal, cmd := a.createModal.Update(msg)
+			a.createModal = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.commentEditor.IsVisible() {
+			newModal, cmd := a.commentEditor.Update(msg)
+			a.commentEditor = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.linkModal.IsVisible() {
+			newModal, cmd := a.linkModal.Update(msg)
+			a.linkModal = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.sprintModal.IsVisible() {
+			newModal, cmd := a.sprintModal.Update(msg)
+			a.sprintModal = newModal
+			return a, cmd
+		}
+
+		if a.bulkModal.IsVisible() {
+			newModal, cmd := a.bulkModal.Update(msg)
+			a.bulkModal = newModal
+			return a, cmd
+		}
+
+		if a.attachModal.IsVisible() {
+			newModal, cmd := a.attachModal.Update(msg)
+			a.attachModal = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.wiqlEditor.IsVisible() {
+			newModal, cmd := a.wiqlEditor.Update(msg)
+			a.wiqlEditor = newModal
+			return a, cmd
+		}
+
 		// Global keys
 		if key.Matches(msg, a.keys.Quit) && !a.helpPanel.IsVisible() && a.viewMode == ViewMain {
 			return a, tea.Quit
@@ -164,6 +347,85 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 
+		// Don't open editors for changes that can't be saved while offline
+		if a.offline && a.isEditKey(msg) {
+			a.statusMsg = offlineNotice
+			a.detailView.SetNotice(offlineNotice)
+			return a, nil
+		}
+
+		// Handle cycle time view mode
+		if a.viewMode == ViewCycleTime {
+			_, cmd := a.cycleTimeView.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		// Handle flow view mode
+		if a.viewMode == ViewFlow {
+			_, cmd := a.flowView.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		// Handle velocity view mode
+		if a.viewMode == ViewVelocity {
+			_, cmd := a.velocityView.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		// Handle sprint dashboard mode
+		if a.viewMode == ViewSprint {
+			_, cmd := a.sprintView.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		// Handle taskboard view mode
+		if a.viewMode == ViewTaskboard {
+			_, cmd := a.taskboardView.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		// Handle board view mode
+		if a.viewMode == ViewBoard {
+			_, cmd := a.boardView.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		// Handle planning view mode
+		if a.viewMode == ViewPlanning {
+			_, cmd := a.planningView.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		// Handle history view mode
+		if a.viewMode == ViewHistory {
+			_, cmd := a.historyView.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
 		// Handle detail view mode
 		if a.viewMode == ViewDetail {
 			_, cmd := a.detailView.Update(msg)
@@ -185,9 +447,19 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Refresh
 		if key.Matches(msg, a.keys.Refresh) {
-			a.loading = true
 			a.statusMsg = ""
-			return a, loadWorkItemsCmd(a.client, a.filterPanel.FilterState())
+			a.workItemsPanel.ClearChanges()
+			if !a.metadataLoaded {
+				// Startup didn't reach the server; try again from the start
+				a.loading = true
+				return a, loadDataCmd(a.client, a.cache)
+			}
+			return a, a.reloadWorkItems()
+		}
+
+		// Sign in again after the token was rejected
+		if key.Matches(msg, a.keys.SignIn) && canSignIn(a.err, a.cfg) {
+			return a, signInCmd()
 		}
 
 		// Open state change modal (only when work items panel is active)
@@ -221,6 +493,136 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Open field editor (only when work items panel is active)
+		if key.Matches(msg, a.keys.EditFields) && a.activePanel == PanelWorkItems {
+			if item := a.workItemsPanel.SelectedItem(); item != nil {
+				a.fieldEditor.SetItem(item)
+				a.fieldEditor.SetSize(a.width, a.height)
+				a.fieldEditor.SetVisible(true)
+				if defs, ok := a.fieldDefs[string(item.Type)]; ok {
+					a.fieldEditor.SetDefinitions(defs)
+					return a, nil
+				}
+				return a, loadFieldDefinitionsCmd(a.client, string(item.Type))
+			}
+		}
+
+		// Show history (only when work items panel is active)
+		if key.Matches(msg, a.keys.History) && a.activePanel == PanelWorkItems {
+			if item := a.workItemsPanel.SelectedItem(); item != nil {
+				return a, a.showHistory(*item)
+			}
+		}
+
+		// Open move to sprint modal (only when work items panel is active)
+		if key.Matches(msg, a.keys.MoveToSprint) && a.activePanel == PanelWorkItems {
+			if item := a.workItemsPanel.SelectedItem(); item != nil {
+				a.sprintModal.SetIterations(a.iterations, a.backlogIterationPath())
+				a.sprintModal.SetItem(item)
+				a.sprintModal.SetSize(a.width, a.height)
+				a.sprintModal.SetVisible(true)
+				return a, nil
+			}
+		}
+
+		// Open the WIQL editor, with the query the list shows if any
+		if key.Matches(msg, a.keys.WIQLQuery) {
+			history, _ := config.LoadQueryHistory()
+			a.wiqlEditor.SetSize(a.width, a.height)
+			return a, a.wiqlEditor.Open(a.wiqlQuery, history)
+		}
+
+		// Switch to sprint planning
+		if key.Matches(msg, a.keys.SprintPlanning) {
+			a.openView()
+			return a, a.showPlanning()
+		}
+
+		// Switch to the cycle and lead time report
+		if key.Matches(msg, a.keys.CycleTime) {
+			a.viewMode = ViewCycleTime
+			a.cycleTimeView.SetSize(a.width, a.height)
+			return a, a.loadCycleTime()
+		}
+
+		// Switch to the cumulative flow diagram
+		if key.Matches(msg, a.keys.CumulativeFlow) {
+			a.viewMode = ViewFlow
+			a.flowView.SetIterations(a.iterations, a.filterPanel.FilterState().GetSelectedSprint())
+			a.flowView.SetSize(a.width, a.height)
+			return a, a.loadFlow()
+		}
+
+		// Switch to the velocity of the past sprints
+		if key.Matches(msg, a.keys.Velocity) {
+			a.viewMode = ViewVelocity
+			a.velocityView.SetSize(a.width, a.height)
+			return a, a.loadVelocity()
+		}
+
+		// Switch to the dashboard of the filtered sprint
+		if key.Matches(msg, a.keys.SprintDashboard) {
+			a.viewMode = ViewSprint
+			a.sprintView.SetIterations(a.iterations, a.filterPanel.FilterState().GetSelectedSprint())
+			a.sprintView.SetSize(a.width, a.height)
+			return a, a.loadSprintView()
+		}
+
+		// Switch to the Kanban board of the listed items
+		if key.Matches(msg, a.keys.KanbanBoard) {
+			a.viewMode = ViewBoard
+			a.openView()
+			a.boardView.SetItems(a.workItems)
+			a.boardView.SetSize(a.width, a.height)
+			if !a.boardView.HasBoards() {
+				return a, a.loadBoards()
+			}
+			return a, nil
+		}
+
+		// Switch to the taskboard of the listed tasks
+		if key.Matches(msg, a.keys.Taskboard) {
+			a.viewMode = ViewTaskboard
+			a.openView()
+			a.taskboardView.SetStates(a.statesByType)
+			a.taskboardView.SetItems(a.workItems)
+			a.taskboardView.SetSize(a.width, a.height)
+			return a, nil
+		}
+
+		// Open bulk actions for the marked work items
+		if key.Matches(msg, a.keys.BulkActions) && a.activePanel == PanelWorkItems {
+			items := a.workItemsPanel.MarkedItems()
+			if len(items) == 0 {
+				a.statusMsg = "Mark work items with Space first"
+				return a, nil
+			}
+			a.bulkModal.SetItems(items)
+			a.bulkModal.SetChoices(a.statesByType, a.teamMembers, a.iterations, a.areas, knownTags(a.workItems))
+			a.bulkModal.SetSize(a.width, a.height)
+			a.bulkModal.SetVisible(true)
+			return a, nil
+		}
+
+		// Open new work item modal, pre-filled from the active filters
+		if key.Matches(msg, a.keys.NewWorkItem) && a.activePanel == PanelWorkItems {
+			fs := a.filterPanel.FilterState()
+			iteration, area := fs.GetSelectedSprint(), fs.GetSelectedArea()
+			if iteration == "all" {
+				iteration = ""
+			}
+			if area == "all" {
+				area = ""
+			}
+			a.createModal.Reset(iteration, area, a.workItemsPanel.SelectedItem())
+			a.createModal.SetSize(a.width, a.height)
+			a.createModal.SetVisible(true)
+			if !a.createModal.HasTypes() {
+				return a, loadWorkItemTypesCmd(a.client)
+			}
+			return a, textinput.Blink
+		}
+
 		// Update active panel
 		switch a.activePanel {
 		case PanelFilter:
@@ -243,31 +645,119 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case dataLoadedMsg:
-		a.iterations = msg.iterations
-		a.areas = msg.areas
-		a.statesByType = msg.statesByType
-		a.teamMembers = msg.teamMembers
-		a.stateModal.SetStatesByType(a.statesByType)
-		filterState := models.NewFilterState(a.iterations, a.areas, a.statesByType)
-
-		// Apply saved filter selections
-		if savedState, err := config.LoadFilterState(); err == nil {
-			filterState.ApplySavedSelections(savedState.Sprint, savedState.State, savedState.Assigned, savedState.Area)
+	case cacheLoadedMsg:
+		// Show what was cached until the server answers
+		if a.metadataLoaded || msg.metadata == nil {
+			return a, nil
 		}
+		m := msg.metadata
+		a.applyMetadata(m.Iterations, m.Areas, m.StatesByType, m.TeamMembers, m.CurrentUser)
+		a.showCachedItems()
 
-		a.filterPanel.SetFilterState(filterState)
+	case dataLoadedMsg:
+		a.metadataLoaded = true
+		a.offline = false
+		a.applyMetadata(msg.iterations, msg.areas, msg.statesByType, msg.teamMembers, msg.currentUser)
 		// Load work items with initial filters
-		return a, loadWorkItemsCmd(a.client, filterState)
+		return a, a.reloadWorkItems()
 
 	case workItemsLoadedMsg:
+		if msg.seq != a.loadSeq {
+			// Result of a superseded filter - a newer load is on its way
+			return a, nil
+		}
 		a.loading = false
+		if a.wiqlEditor.IsRunning() {
+			// The query from the editor ran; list its results from now on
+			a.wiqlEditor.SetVisible(false)
+			history, _ := config.LoadQueryHistory()
+			_ = config.SaveQueryHistory(config.AddToQueryHistory(history, msg.wiql))
+			a.wiqlQuery = msg.wiql
+			a.workItemsPanel.ClearChanges()
+		} else if msg.wiql != a.wiqlQuery {
+			// The editor that ran the query was closed before it finished
+			return a, nil
+		}
+		if msg.err != nil {
+			// Offline; these are the cached results
+			a.offline = true
+			a.err = msg.err
+			a.staleSince = msg.cachedAt
+		} else {
+			a.offline = false
+			a.staleSince = time.Time{}
+			if api.IsOffline(a.err) {
+				a.err = nil
+			}
+		}
+		if msg.poll {
+			a.addRefreshChanges(msg.items)
+		}
 		a.workItems = msg.items
+		a.workItemsPanel.SetHierarchy(msg.links)
 		a.workItemsPanel.SetItems(msg.items)
+		if a.viewMode == ViewBoard {
+			a.boardView.SetItems(msg.items)
+		}
+		if a.viewMode == ViewTaskboard {
+			a.taskboardView.SetItems(msg.items)
+		}
 		a.updateSelectedItem()
 
+	case refreshTickMsg:
+		cmds = append(cmds, refreshTickCmd(a.cfg.RefreshInterval))
+		// Only refresh the list while it's shown and nothing else is loading it
+		switch {
+		case a.viewMode != ViewMain || a.loading:
+		case !a.metadataLoaded:
+			// Started offline; try again from the start
+			a.loading = true
+			cmds = append(cmds, loadDataCmd(a.client, a.cache))
+		default:
+			cmds = append(cmds, a.pollWorkItems())
+		}
+		return a, tea.Batch(cmds...)
+
+	case wiqlErrMsg:
+		if msg.seq != a.loadSeq {
+			return a, nil
+		}
+		a.loading = false
+		if a.wiqlEditor.IsRunning() {
+			// Most likely a syntax error; show it next to the query
+			a.wiqlEditor.SetError(describeError(msg.err))
+			return a, nil
+		}
+		a.err = msg.err
+		if api.IsOffline(msg.err) {
+			a.offline = true
+		}
+
+	case components.WIQLRunMsg:
+		return a, a.runWIQL(msg.Query)
+
+	case components.WIQLClearMsg:
+		a.wiqlQuery = ""
+		a.workItemsPanel.ClearChanges()
+		a.showCachedItems()
+		return a, a.reloadWorkItems()
+
+	case components.EditWIQLInEditorMsg:
+		return a, editInExternalEditor(msg.Text, func(text string, err error) tea.Msg {
+			if err != nil {
+				return editorErrMsg{err: err}
+			}
+			return wiqlComposedMsg{text: text}
+		})
+
+	case wiqlComposedMsg:
+		a.wiqlEditor.SetText(msg.text)
+
+	case components.TreeModeChangedMsg:
+		a.workItemsPanel.ClearChanges()
+		return a, a.reloadWorkItems()
+
 	case components.FilterChangedMsg:
-		a.loading = true
 		fs := a.filterPanel.FilterState()
 
 		// Save filter selections for next startup
@@ -278,105 +768,672 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			Area:     fs.GetSelectedArea(),
 		})
 
-		return a, loadWorkItemsCmd(a.client, fs)
+		// Choosing a filter leaves the WIQL query
+		a.wiqlQuery = ""
+		a.workItemsPanel.ClearChanges()
+		a.showCachedItems()
+		return a, a.reloadWorkItems()
 
 	case components.OpenWorkItemMsg:
 		if err := browser.Open(msg.Item.WebURL); err != nil {
 			a.err = err
 		}
 
+	case components.OpenURLMsg:
+		if err := browser.Open(msg.URL); err != nil {
+			a.detailView.SetNotice("Failed to open browser: " + describeError(err))
+		}
+
 	case components.ViewWorkItemMsg:
 		a.viewMode = ViewDetail
+		a.cancelDetailLoad()
+		ctx, cancel := context.WithCancel(context.Background())
+		a.detailCancel = cancel
 		// Load full work item details including comments
-		return a, loadFullWorkItemCmd(a.client, msg.Item.ID)
+		return a, loadFullWorkItemCmd(ctx, a.client, msg.Item.ID)
 
 	case fullWorkItemLoadedMsg:
 		a.detailView.SetItem(msg.item)
 		a.updateSizes()
+		return a, loadAttachmentUploadersCmd(a.client, *msg.item)
 
 	case components.CloseDetailViewMsg:
 		a.viewMode = ViewMain
+		a.cancelDetailLoad()
 
-	case errMsg:
-		a.loading = false
-		a.err = msg.err
+	case components.ShowHistoryMsg:
+		return a, a.showHistory(msg.Item)
 
-	case components.ModalClosedMsg:
-		// Modal was closed, nothing special to do
-		a.stateModal.SetVisible(false)
-		a.branchModal.SetVisible(false)
-		a.assignModal.SetVisible(false)
+	case historyLoadedMsg:
+		if a.historyView.ItemID() == msg.id {
+			a.historyView.SetRevisions(msg.revisions)
+		}
 
-	case components.StateChangeRequestMsg:
-		a.stateModal.SetVisible(false)
+	case historyErrMsg:
+		if a.historyView.ItemID() == msg.id {
+			a.historyView.SetError("Failed to load history: " + describeError(msg.err))
+		}
+
+	case components.CloseHistoryMsg:
+		a.viewMode = a.historyReturn
+		if a.historyCancel != nil {
+			a.historyCancel()
+			a.historyCancel = nil
+		}
+
+	case components.MoveToSprintRequestMsg:
+		if a.viewMode == ViewPlanning {
+			return a, planningMoveCmd(a.viewCtx, a.client, msg)
+		}
+		a.sprintModal.SetVisible(false)
 		a.loading = true
-		return a, updateWorkItemStateCmd(a.client, msg.Item.ID, msg.NewState, a.filterPanel.FilterState())
+		changes := []components.FieldChange{{
+			Field: "System.IterationPath",
+			Label: "Iteration",
+			Base:  msg.Item.IterationPath,
+			Ours:  msg.IterationPath,
+			Value: msg.IterationPath,
+		}}
+		done := iterationChangedMsg{id: msg.Item.ID, name: msg.IterationName}
+		return a, updateFieldsCmd(a.client, msg.Item.ID, msg.Item.Rev, changes, done)
+
+	case iterationChangedMsg:
+		a.loading = false
+		a.statusMsg = fmt.Sprintf("Moved #%d to %s", msg.id, msg.name)
+		return a, a.reloadWorkItems()
 
-	case stateChangedMsg:
+	case components.ReloadPlanningMsg:
+		return a, a.showPlanning()
+
+	case planningLoadedMsg:
+		if msg.seq != a.loadSeq {
+			return a, nil
+		}
 		a.loading = false
-		a.statusMsg = fmt.Sprintf("State changed to %s", msg.newState)
-		// Refresh work items to show updated state
-		return a, loadWorkItemsCmd(a.client, a.filterPanel.FilterState())
+		// Finished items stay in the backlog but aren't planned
+		root := a.backlogIterationPath()
+		items := make([]models.WorkItem, 0, len(msg.items))
+		for _, item := range msg.items {
+			if item.IterationPath == root && item.IsClosed(a.statesByType) {
+				continue
+			}
+			items = append(items, item)
+		}
+		a.planningView.SetItems(items)
 
-	case components.BranchCreateRequestMsg:
-		a.branchModal.SetVisible(false)
-		return a, createBranchCmd(msg.BranchName)
+	case planningErrMsg:
+		if msg.seq != a.loadSeq {
+			return a, nil
+		}
+		a.loading = false
+		a.planningView.SetError("Failed to load backlog: " + describeError(msg.err))
 
-	case components.BranchCreatedMsg:
-		a.statusMsg = fmt.Sprintf("Branch created: %s", msg.BranchName)
+	case planningMovedMsg:
+		a.planningView.MoveDone(msg.item, fmt.Sprintf("Moved #%d to %s", msg.item.ID, msg.name))
 
-	case components.BranchCreateErrorMsg:
-		a.err = msg.Err
+	case planningMoveErrMsg:
+		a.planningView.MoveFailed(msg.id, fmt.Sprintf("Moving #%d failed: %s", msg.id, describeError(msg.err)))
 
-	case components.AssignRequestMsg:
-		a.assignModal.SetVisible(false)
-		a.loading = true
-		return a, assignWorkItemCmd(a.client, msg.Item.ID, msg.UserEmail, msg.UserName, a.filterPanel.FilterState())
+	case boardsLoadedMsg:
+		a.boardView.SetBoards(msg.boards)
 
-	case assignedMsg:
-		a.loading = false
-		a.statusMsg = fmt.Sprintf("Assigned to %s", msg.userName)
-		// Refresh work items to show updated assignment
-		return a, loadWorkItemsCmd(a.client, a.filterPanel.FilterState())
-	}
+	case boardsErrMsg:
+		a.boardView.SetError("Failed to load boards: " + describeError(msg.err))
 
-	// Update selected item in details panel
-	a.updateSelectedItem()
+	case components.BoardMoveRequestMsg:
+		return a, boardMoveCmd(a.viewCtx, a.client, msg)
 
-	return a, tea.Batch(cmds...)
-}
+	case boardMovedMsg:
+		a.boardView.MoveDone(msg.item, fmt.Sprintf("Moved #%d to %s", msg.item.ID, msg.column))
 
-// View renders the application
-func (a App) View() string {
-	if a.width == 0 || a.height == 0 {
-		return "Loading..."
-	}
+	case boardMoveErrMsg:
+		a.boardView.MoveFailed(msg.id, fmt.Sprintf("Moving #%d failed: %s", msg.id, describeError(msg.err)))
 
-	// Render state modal if visible
-	if a.stateModal.IsVisible() {
-		return a.stateModal.View()
-	}
+	case components.ReloadBoardMsg:
+		return a, tea.Batch(a.loadBoards(), a.reloadWorkItems())
 
-	// Render branch modal if visible
-	if a.branchModal.IsVisible() {
-		return a.branchModal.View()
-	}
+	case components.TaskMoveRequestMsg:
+		return a, taskMoveCmd(a.viewCtx, a.client, msg)
 
-	// Render assign modal if visible
-	if a.assignModal.IsVisible() {
-		return a.assignModal.View()
-	}
+	case taskMovedMsg:
+		a.taskboardView.MoveDone(msg.item, fmt.Sprintf("Moved #%d to %s", msg.item.ID, msg.item.State))
 
-	// Render help overlay if visible
-	if a.helpPanel.IsVisible() {
-		_ = a.renderMainView()
-		help := a.helpPanel.View()
-		return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, help)
-	}
+	case taskMoveErrMsg:
+		a.taskboardView.MoveFailed(msg.id, fmt.Sprintf("Moving #%d failed: %s", msg.id, describeError(msg.err)))
 
-	// Render detail view if in detail mode
-	if a.viewMode == ViewDetail {
-		return a.detailView.View()
+	case components.ReloadTaskboardMsg:
+		return a, a.reloadWorkItems()
+
+	case components.ReloadCycleTimeMsg:
+		return a, a.loadCycleTime()
+
+	case cycleTimeLoadedMsg:
+		a.cycleTimeView.SetTimings(msg.timings)
+
+	case cycleTimeErrMsg:
+		a.cycleTimeView.SetError("Failed to load state history: " + describeError(msg.err))
+
+	case components.CloseCycleTimeMsg:
+		a.viewMode = ViewMain
+		if a.cycleCancel != nil {
+			a.cycleCancel()
+			a.cycleCancel = nil
+		}
+
+	case components.ReloadFlowMsg:
+		return a, a.loadFlow()
+
+	case flowLoadedMsg:
+		a.flowView.SetFlow(msg.days)
+
+	case flowErrMsg:
+		a.flowView.SetError("Failed to load state history: " + describeError(msg.err))
+
+	case components.CloseFlowMsg:
+		a.viewMode = ViewMain
+		if a.flowCancel != nil {
+			a.flowCancel()
+			a.flowCancel = nil
+		}
+
+	case components.ReloadVelocityMsg:
+		return a, a.loadVelocity()
+
+	case velocityLoadedMsg:
+		a.velocityView.SetVelocity(models.Velocity(msg.iterations, msg.items, a.statesByType))
+
+	case velocityErrMsg:
+		a.velocityView.SetError("Failed to load past sprints: " + describeError(msg.err))
+
+	case components.CloseVelocityMsg:
+		a.viewMode = ViewMain
+		if a.velocityCancel != nil {
+			a.velocityCancel()
+			a.velocityCancel = nil
+		}
+
+	case components.ReloadSprintViewMsg:
+		return a, a.loadSprintView()
+
+	case sprintViewLoadedMsg:
+		a.sprintView.SetData(msg.capacity, msg.items, msg.burndown)
+
+	case sprintViewErrMsg:
+		a.sprintView.SetError("Failed to load sprint: " + describeError(msg.err))
+
+	case components.CloseSprintViewMsg:
+		a.viewMode = ViewMain
+		if a.sprintCancel != nil {
+			a.sprintCancel()
+			a.sprintCancel = nil
+		}
+
+	case components.CloseTaskboardMsg:
+		a.viewMode = ViewMain
+		a.closeView()
+		return a, a.reloadWorkItems()
+
+	case components.CloseBoardMsg:
+		a.viewMode = ViewMain
+		a.closeView()
+		// Columns and states of listed items may have changed
+		return a, a.reloadWorkItems()
+
+	case components.ClosePlanningMsg:
+		a.viewMode = ViewMain
+		a.closeView()
+		if a.planningCancel != nil {
+			a.planningCancel()
+			a.planningCancel = nil
+		}
+		// Iterations of listed items may have changed
+		return a, a.reloadWorkItems()
+
+	case errMsg:
+		a.loading = false
+		a.err = msg.err
+		if api.IsOffline(msg.err) {
+			a.offline = true
+		}
+		var notFound *api.NotFoundError
+		if errors.As(msg.err, &notFound) && a.viewMode == ViewDetail {
+			// The opened item was deleted; go back to the list as it is now
+			a.viewMode = ViewMain
+			a.cancelDetailLoad()
+			return a, a.reloadWorkItems()
+		}
+
+	case updateErrMsg:
+		a.loading = false
+		a.err = msg.err
+		var (
+			ruleErr  *api.RuleValidationError
+			notFound *api.NotFoundError
+		)
+		switch {
+		case errors.As(msg.err, &ruleErr):
+			// Let the user fix the rejected value and save again
+			return a, a.reopenFieldEditor(msg.id, msg.changes, ruleErr)
+		case errors.As(msg.err, &notFound):
+			// Deleted or moved meanwhile; show the list as it is now
+			return a, a.reloadWorkItems()
+		}
+
+	case signedInMsg:
+		if msg.err != nil {
+			a.err = msg.err
+			return a, nil
+		}
+		a.client.SetAccessToken(msg.token)
+		a.cfg.SetAccessToken(msg.token)
+		a.err = nil
+		a.statusMsg = "Signed in"
+		if !a.metadataLoaded {
+			a.loading = true
+			return a, loadDataCmd(a.client, a.cache)
+		}
+		return a, a.reloadWorkItems()
+
+	case components.ModalClosedMsg:
+		// Modal was closed, nothing special to do
+		a.stateModal.SetVisible(false)
+		a.branchModal.SetVisible(false)
+		a.assignModal.SetVisible(false)
+		a.fieldEditor.SetVisible(false)
+		a.createModal.SetVisible(false)
+		a.commentEditor.SetVisible(false)
+		a.linkModal.SetVisible(false)
+		a.attachModal.SetVisible(false)
+		a.bulkModal.SetVisible(false)
+		a.sprintModal.SetVisible(false)
+		a.wiqlEditor.SetVisible(false)
+		if a.attachCancel != nil {
+			a.attachCancel()
+			a.attachCancel = nil
+		}
+		if a.bulk != nil {
+			return a, a.cancelBulk()
+		}
+
+	case components.StateChangeRequestMsg:
+		a.stateModal.SetVisible(false)
+		a.loading = true
+		return a, updateWorkItemStateCmd(a.client, msg.Item, msg.NewState)
+
+	case stateChangedMsg:
+		a.loading = false
+		a.statusMsg = fmt.Sprintf("State changed to %s", msg.newState)
+		// Refresh work items to show updated state
+		return a, a.reloadWorkItems()
+
+	case components.BranchCreateRequestMsg:
+		a.branchModal.SetVisible(false)
+		return a, createBranchCmd(msg.BranchName)
+
+	case components.BranchCreatedMsg:
+		a.statusMsg = fmt.Sprintf("Branch created: %s", msg.BranchName)
+
+	case components.BranchCreateErrorMsg:
+		a.err = msg.Err
+
+	case components.AssignRequestMsg:
+		a.assignModal.SetVisible(false)
+		a.loading = true
+		return a, assignWorkItemCmd(a.client, msg.Item, msg.UserEmail, msg.UserName)
+
+	case assignedMsg:
+		a.loading = false
+		a.statusMsg = fmt.Sprintf("Assigned to %s", msg.userName)
+		// Refresh work items to show updated assignment
+		return a, a.reloadWorkItems()
+
+	case fieldDefinitionsLoadedMsg:
+		if msg.defs != nil {
+			a.fieldDefs[msg.workItemType] = msg.defs
+		}
+		if a.fieldEditor.ItemType() == msg.workItemType {
+			a.fieldEditor.SetDefinitions(msg.defs)
+		}
+
+	case components.FieldUpdateRequestMsg:
+		a.fieldEditor.SetVisible(false)
+		a.loading = true
+		done := fieldsUpdatedMsg{id: msg.Item.ID, count: len(msg.Changes)}
+		return a, updateFieldsCmd(a.client, msg.Item.ID, msg.Item.Rev, msg.Changes, done)
+
+	case fieldsUpdatedMsg:
+		a.loading = false
+		a.statusMsg = fmt.Sprintf("Updated %d field(s) on #%d", msg.count, msg.id)
+		return a, a.reloadWorkItems()
+
+	case workItemTypesLoadedMsg:
+		a.createModal.SetTypes(msg.types)
+
+	case components.CreateWorkItemRequestMsg:
+		a.createModal.SetVisible(false)
+		a.loading = true
+		return a, createWorkItemCmd(a.client, msg)
+
+	case workItemCreatedMsg:
+		a.loading = false
+		a.workItems = append(a.workItems, msg.item)
+		a.workItemsPanel.AddItem(msg.item)
+		a.statusMsg = fmt.Sprintf("Created %s #%d", msg.item.Type, msg.item.ID)
+
+	case components.EditLongTextMsg:
+		if msg.Pending {
+			return a, editLongTextCmd(msg.Field, msg.Label, msg.Text)
+		}
+		return a, loadLongTextCmd(a.client, msg)
+
+	case longTextLoadedMsg:
+		return a, editLongTextCmd(msg.field, msg.label, msg.markdown)
+
+	case longTextEditedMsg:
+		markdown := strings.TrimSpace(msg.markdown)
+		if markdown == strings.TrimSpace(msg.original) {
+			return a, nil
+		}
+		html, err := markup.MarkdownToHTML(markdown)
+		if err != nil {
+			a.fieldEditor.SetError(err)
+			return a, nil
+		}
+		a.fieldEditor.SetLongText(msg.field, msg.label, markdown, html)
+
+	case components.ComposeCommentInEditorMsg:
+		return a, editInExternalEditor(msg.Text, func(text string, err error) tea.Msg {
+			if err != nil {
+				return editorErrMsg{err: err}
+			}
+			return commentComposedMsg{text: text}
+		})
+
+	case commentComposedMsg:
+		a.commentEditor.SetText(msg.text)
+
+	case editorErrMsg:
+		if a.commentEditor.IsVisible() {
+			a.commentEditor.SetError(describeError(msg.err))
+		} else if a.wiqlEditor.IsVisible() {
+			a.wiqlEditor.SetError(describeError(msg.err))
+		} else {
+			a.fieldEditor.SetError(msg.err)
+		}
+
+	case components.ComposeCommentMsg:
+		a.commentEditor.SetSize(a.width, a.height)
+		return a, a.commentEditor.Open(msg.WorkItemID, msg.CommentID, msg.Text)
+
+	case components.CommentSubmitMsg:
+		a.commentEditor.SetVisible(false)
+		return a, saveCommentCmd(a.client, msg)
+
+	case components.DeleteCommentRequestMsg:
+		return a, deleteCommentCmd(a.client, msg.WorkItemID, msg.CommentID)
+
+	case commentsChangedMsg:
+		if a.detailView.ItemID() == msg.workItemID {
+			a.detailView.SetComments(msg.comments)
+			a.detailView.SetNotice(msg.notice)
+		}
+		a.setCommentCount(msg.workItemID, len(msg.comments))
+
+	case commentErrMsg:
+		a.detailView.SetNotice("Comment failed: " + describeError(msg.err))
+
+	case components.ManageLinksMsg:
+		item := msg.Item
+		a.linkModal.SetItem(&item, a.workItems)
+		a.linkModal.SetSize(a.width, a.height)
+		a.linkModal.SetVisible(true)
+
+	case components.LinkAddRequestMsg:
+		a.loading = true
+		return a, addLinkCmd(a.client, msg)
+
+	case components.LinkRemoveRequestMsg:
+		a.loading = true
+		return a, removeLinkCmd(a.client, msg)
+
+	case relationsChangedMsg:
+		a.loading = false
+		if a.detailView.ItemID() == msg.item.ID {
+			a.detailView.ReplaceItem(msg.item)
+		}
+		if a.linkModal.ItemID() == msg.item.ID {
+			item := *msg.item
+			a.linkModal.SetItem(&item, a.workItems)
+		}
+		if a.attachModal.ItemID() == msg.item.ID {
+			item := *msg.item
+			a.attachModal.SetItem(&item)
+			a.attachModal.SetNotice(msg.notice)
+		}
+		a.statusMsg = msg.notice
+		a.detailView.SetNotice(msg.notice)
+		// Parent and child columns in the list may have changed, and the reloaded
+		// item lists its attachments without uploaders
+		return a, tea.Batch(a.reloadWorkItems(), loadAttachmentUploadersCmd(a.client, *msg.item))
+
+	case linkErrMsg:
+		a.loading = false
+		if a.linkModal.IsVisible() {
+			a.linkModal.SetError(describeError(msg.err))
+		} else {
+			a.detailView.SetNotice("Link failed: " + describeError(msg.err))
+		}
+
+	case components.ManageAttachmentsMsg:
+		item := msg.Item
+		a.attachModal.SetItem(&item)
+		a.attachModal.SetSize(a.width, a.height)
+		a.attachModal.SetVisible(true)
+
+	case attachmentUploadersMsg:
+		if a.detailView.ItemID() == msg.id {
+			a.detailView.SetAttachmentUploaders(msg.uploaders)
+		}
+		if a.attachModal.ItemID() == msg.id {
+			a.attachModal.SetUploaders(msg.uploaders)
+		}
+
+	case components.AttachmentDownloadRequestMsg:
+		return a, downloadAttachmentCmd(a.attachmentContext(), a.client, msg.Attachment, a.cfg.AttachmentDir(), msg.Open)
+
+	case attachmentSavedMsg:
+		notice := "Saved to " + msg.path
+		if msg.opened {
+			notice = "Opened " + filepath.Base(msg.path)
+		}
+		a.attachModal.SetNotice(notice)
+
+	case components.AttachmentUploadRequestMsg:
+		a.loading = true
+		return a, uploadAttachmentCmd(a.attachmentContext(), a.client, msg.ItemID, msg.Path)
+
+	case attachmentErrMsg:
+		a.loading = false
+		if errors.Is(msg.err, context.Canceled) {
+			// The modal was closed during the transfer
+			return a, nil
+		}
+		if a.attachModal.IsVisible() {
+			a.attachModal.SetError(describeError(msg.err))
+		} else {
+			a.detailView.SetNotice("Attachment failed: " + describeError(msg.err))
+		}
+
+	case components.BulkActionRequestMsg:
+		a.bulk = newBulkRun(msg)
+		a.loading = true
+		return a, a.nextBulkChunk()
+
+	case bulkChunkDoneMsg:
+		if a.bulk == nil {
+			return a, nil
+		}
+		a.bulk.addResults(msg.chunk, msg.results, msg.err)
+		return a, a.nextBulkChunk()
+
+	case updateConflictMsg:
+		a.loading = false
+		a.pendingDone = msg.done
+		// Put a moved card back until the conflict is resolved
+		notice := fmt.Sprintf("#%d was changed by someone else", msg.server.ID)
+		switch msg.done.(type) {
+		case components.ReloadPlanningMsg:
+			a.planningView.MoveFailed(msg.server.ID, notice)
+		case components.ReloadBoardMsg:
+			a.boardView.MoveFailed(msg.server.ID, notice)
+		case components.ReloadTaskboardMsg:
+			a.taskboardView.MoveFailed(msg.server.ID, notice)
+		}
+		server := msg.server
+		a.conflictModal.SetConflict(&server, msg.changes)
+		a.conflictModal.SetSize(a.width, a.height)
+		a.conflictModal.SetVisible(true)
+
+	case components.ConflictResolvedMsg:
+		a.conflictModal.SetVisible(false)
+		done := a.pendingDone
+		a.pendingDone = nil
+		if msg.Resolution == components.ConflictDiscard || len(msg.Changes) == 0 {
+			a.statusMsg = fmt.Sprintf("Kept server version of #%d", msg.Server.ID)
+			switch done.(type) {
+			case components.ReloadPlanningMsg, components.ReloadBoardMsg, components.ReloadTaskboardMsg:
+				// Reload the view the move was made in
+				return a, func() tea.Msg { return done }
+			}
+			return a, a.reloadWorkItems()
+		}
+		a.loading = true
+		// Re-apply against the server's current revision
+		return a, updateFieldsCmd(a.client, msg.Server.ID, msg.Server.Rev, msg.Changes, done)
+	}
+
+	// Update selected item in details panel
+	a.updateSelectedItem()
+
+	return a, tea.Batch(cmds...)
+}
+
+// View renders the application
+func (a App) View() string {
+	if a.width == 0 || a.height == 0 {
+		return "Loading..."
+	}
+
+	// Render state modal if visible
+	if a.stateModal.IsVisible() {
+		return a.stateModal.View()
+	}
+
+	// Render branch modal if visible
+	if a.branchModal.IsVisible() {
+		return a.branchModal.View()
+	}
+
+	// Render assign modal if visible
+	if a.assignModal.IsVisible() {
+		return a.assignModal.View()
+	}
+
+	// Render conflict modal if visible
+	if a.conflictModal.IsVisible() {
+		return a.conflictModal.View()
+	}
+
+	// Render field editor if visible
+	if a.fieldEditor.IsVisible() {
+		return a.fieldEditor.View()
+	}
+
+	// Render create modal if visible
+	if a.createModal.IsVisible() {
+		return a.createModal.View()
+	}
+
+	// Render comment editor if visible
+	if a.commentEditor.IsVisible() {
+		return a.commentEditor.View()
+	}
+
+	// Render link modal if visible
+	if a.linkModal.IsVisible() {
+		return a.linkModal.View()
+	}
+
+	// Render move to sprint modal if visible
+	if a.sprintModal.IsVisible() {
+		return a.sprintModal.View()
+	}
+
+	// Render bulk action modal if visible
+	if a.bulkModal.IsVisible() {
+		return a.bulkModal.View()
+	}
+
+	// Render attachment modal if visible
+	if a.attachModal.IsVisible() {
+		return a.attachModal.View()
+	}
+
+	// Render WIQL editor if visible
+	if a.wiqlEditor.IsVisible() {
+		return a.wiqlEditor.View()
+	}
+
+	// Render help overlay if visible
+	if a.helpPanel.IsVisible() {
+		_ = a.renderMainView()
+		help := a.helpPanel.View()
+		return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, help)
+	}
+
+	// Render cycle time view if in cycle time mode
+	if a.viewMode == ViewCycleTime {
+		return a.cycleTimeView.View()
+	}
+
+	// Render flow view if in flow mode
+	if a.viewMode == ViewFlow {
+		return a.flowView.View()
+	}
+
+	// Render velocity view if in velocity mode
+	if a.viewMode == ViewVelocity {
+		return a.velocityView.View()
+	}
+
+	// Render sprint dashboard if in sprint mode
+	if a.viewMode == ViewSprint {
+		return a.sprintView.View()
+	}
+
+	// Render taskboard view if in taskboard mode
+	if a.viewMode == ViewTaskboard {
+		return a.taskboardView.View()
+	}
+
+	// Render board view if in board mode
+	if a.viewMode == ViewBoard {
+		return a.boardView.View()
+	}
+
+	// Render planning view if in planning mode
+	if a.viewMode == ViewPlanning {
+		return a.planningView.View()
+	}
+
+	// Render history view if in history mode
+	if a.viewMode == ViewHistory {
+		return a.historyView.View()
+	}
+
+	// Render detail view if in detail mode
+	if a.viewMode == ViewDetail {
+		return a.detailView.View()
 	}
 
 	return a.renderMainView()
@@ -415,10 +1472,34 @@ func (a *App) renderMainView() string {
 		titleBar += "  " + a.styles.Subtitle.Render("Loading...")
 	}
 
-	// Error display
-	if a.err != nil {
+	// WIQL query indicator; the filters don't apply while it's shown
+	if a.wiqlQuery != "" {
+		query := []rune(strings.Join(strings.Fields(a.wiqlQuery), " "))
+		if len(query) > 40 {
+			query = append(query[:40], []rune("...")...)
+		}
+		titleBar += "  " + lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#A78BFA")).Render("WIQL: "+string(query))
+	}
+
+	// Cached data and offline indicator
+	if a.offline || !a.staleSince.IsZero() {
+		text := "Cached"
+		if a.offline {
+			text = "Offline · read-only"
+		}
+		if !a.staleSince.IsZero() {
+			text += " · stale since " + formatStaleSince(a.staleSince)
+		}
+		titleBar += "  " + lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F59E0B")).Render(text)
+	}
+
+	// Error display, unless it's the offline indicator's
+	if a.err != nil && !(a.offline && api.IsOffline(a.err)) {
 		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
-		titleBar += "  " + errStyle.Render(a.err.Error())
+		titleBar += "  " + errStyle.Render(describeError(a.err))
+		if canSignIn(a.err, a.cfg) {
+			titleBar += "  " + a.styles.Subtitle.Render("(R: sign in again)")
+		}
 	}
 
 	// Status message
@@ -467,6 +1548,16 @@ func (a *App) renderStatusBar() string {
 	}
 	parts = append(parts, a.styles.HelpKey.Render("Panel")+": "+panelName)
 
+	// Warn before Azure DevOps starts throttling us
+	if rl := a.client.RateLimit(); rl.IsLow() {
+		warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+		warning := fmt.Sprintf("Rate limit: %d/%d left", rl.Remaining, rl.Limit)
+		if rl.Delay > 0 {
+			warning = fmt.Sprintf("Throttled: requests delayed %.1fs", rl.Delay.Seconds())
+		}
+		parts = append(parts, warnStyle.Render(warning))
+	}
+
 	// Short help
 	help := components.ShortHelp(a.keys, a.styles)
 	parts = append(parts, help)
@@ -505,27 +1596,475 @@ func (a *App) updateFocus() {
 func (a *App) updateSizes() {
 	a.helpPanel.SetSize(a.width, a.height)
 	a.detailView.SetSize(a.width, a.height)
+	a.historyView.SetSize(a.width, a.height)
+	a.planningView.SetSize(a.width, a.height)
+	a.boardView.SetSize(a.width, a.height)
+	a.taskboardView.SetSize(a.width, a.height)
+	a.sprintView.SetSize(a.width, a.height)
+	a.velocityView.SetSize(a.width, a.height)
+	a.flowView.SetSize(a.width, a.height)
+	a.cycleTimeView.SetSize(a.width, a.height)
 	a.updateFocus()
 }
 
-func (a *App) updateSelectedItem() {
-	item := a.workItemsPanel.SelectedItem()
-	a.detailsPanel.SetItem(item)
+// reloadWorkItems cancels any in-flight work item load and starts one for the current filters
+func (a *App) reloadWorkItems() tea.Cmd {
+	if a.loadCancel != nil {
+		a.loadCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.loadCancel = cancel
+	a.loadSeq++
+	a.loading = true
+	if a.wiqlQuery != "" {
+		return loadWIQLCmd(ctx, a.client, a.cache, a.wiqlQuery, a.loadSeq, false)
+	}
+	return loadWorkItemsCmd(ctx, a.client, a.cache, a.filterPanel.FilterState(), a.workItemsPanel.IsTreeMode(), a.loadSeq, false)
 }
 
-// Message types
-
-type dataLoadedMsg struct {
-	iterations   []models.Iteration
-	areas        []models.Area
-	statesByType map[string][]models.WorkItemStateInfo
-	teamMembers  []models.TeamMember
+// runWIQL loads the results of a WIQL query from the editor into the list. The
+// list switches to the query once it succeeds.
+func (a *App) runWIQL(query string) tea.Cmd {
+	if a.loadCancel != nil {
+		a.loadCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.loadCancel = cancel
+	a.loadSeq++
+	a.loading = true
+	return loadWIQLCmd(ctx, a.client, a.cache, query, a.loadSeq, false)
 }
 
-type workItemsLoadedMsg struct {
-	items []models.WorkItem
+// applyMetadata sets the project data, from the server or the cache, and builds
+// the filters from it
+func (a *App) applyMetadata(iterations []models.Iteration, areas []models.Area, statesByType map[string][]models.WorkItemStateInfo, teamMembers []models.TeamMember, currentUser *models.TeamMember) {
+	a.iterations = iterations
+	a.areas = areas
+	a.statesByType = statesByType
+	a.teamMembers = teamMembers
+	if currentUser != nil {
+		a.detailView.SetCurrentUserID(currentUser.ID)
+	}
+	a.stateModal.SetStatesByType(a.statesByType)
+	filterState := models.NewFilterState(a.iterations, a.areas, a.statesByType)
+
+	// Apply saved filter selections
+	if savedState, err := config.LoadFilterState(); err == nil {
+		filterState.ApplySavedSelections(savedState.Sprint, savedState.State, savedState.Assigned, savedState.Area)
+	}
+
+	a.filterPanel.SetFilterState(filterState)
 }
 
+// showCachedItems lists the cached result of the current filters, if there is one
+func (a *App) showCachedItems() {
+	fs := a.filterPanel.FilterState()
+	key := cache.QueryKey(fs.GetSelectedSprint(), fs.GetSelectedState(), fs.GetSelectedAssigned(), fs.GetSelectedArea(), a.workItemsPanel.IsTreeMode())
+	items, q, ok := a.cache.Query(key)
+	if !ok {
+		return
+	}
+	a.workItems = items
+	a.workItemsPanel.SetHierarchy(q.Links)
+	a.workItemsPanel.SetItems(items)
+	a.staleSince = q.SyncedAt
+	a.updateSelectedItem()
+}
+
+// reopenFieldEditor opens the field editor again with changes the process rules
+// rejected, on the rejected field. The error stays in the title bar if the field
+// isn't one the editor offers or the item isn't listed.
+func (a *App) reopenFieldEditor(id int, changes []components.FieldChange, ruleErr *api.RuleValidationError) tea.Cmd {
+	var item *models.WorkItem
+	for i := range a.workItems {
+		if a.workItems[i].ID == id {
+			item = &a.workItems[i]
+			break
+		}
+	}
+	if item == nil {
+		return nil
+	}
+
+	edited := *item
+	a.fieldEditor.SetItem(&edited)
+	if !a.fieldEditor.Restore(changes, ruleErr.Field, errors.New(describeError(ruleErr))) {
+		return nil
+	}
+	a.err = nil
+	a.fieldEditor.SetSize(a.width, a.height)
+	a.fieldEditor.SetVisible(true)
+	if defs, ok := a.fieldDefs[string(item.Type)]; ok {
+		a.fieldEditor.SetDefinitions(defs)
+		return nil
+	}
+	return loadFieldDefinitionsCmd(a.client, string(item.Type))
+}
+
+// isEditKey reports whether a key starts a change to work items in the current view
+func (a *App) isEditKey(msg tea.KeyMsg) bool {
+	switch a.viewMode {
+	case ViewMain:
+		return a.activePanel == PanelWorkItems && key.Matches(msg, a.keys.ChangeState, a.keys.Assign,
+			a.keys.EditFields, a.keys.MoveToSprint, a.keys.BulkActions, a.keys.NewWorkItem)
+	case ViewDetail:
+		return key.Matches(msg, a.keys.AddComment, a.keys.EditComment, a.keys.DeleteComment,
+			a.keys.ManageLinks, a.keys.ManageAttachments)
+	}
+	return false
+}
+
+// refuseOffline rejects a request to change work items, putting back what the view
+// or modal that sent it already showed. Returns false for any other message.
+func (a *App) refuseOffline(msg tea.Msg) bool {
+	switch msg := msg.(type) {
+	case components.MoveToSprintRequestMsg:
+		if a.viewMode == ViewPlanning {
+			a.planningView.MoveFailed(msg.Item.ID, offlineNotice)
+		}
+		a.sprintModal.SetVisible(false)
+	case components.BoardMoveRequestMsg:
+		a.boardView.MoveFailed(msg.Item.ID, offlineNotice)
+	case components.TaskMoveRequestMsg:
+		a.taskboardView.MoveFailed(msg.Item.ID, offlineNotice)
+	case components.StateChangeRequestMsg:
+		a.stateModal.SetVisible(false)
+	case components.AssignRequestMsg:
+		a.assignModal.SetVisible(false)
+	case components.BulkActionRequestMsg:
+		a.bulkModal.SetVisible(false)
+	case components.FieldUpdateRequestMsg:
+		// Keep the editor open with the pending changes
+		a.fieldEditor.SetError(errors.New(offlineNotice))
+	case components.CreateWorkItemRequestMsg:
+		a.createModal.SetError(offlineNotice)
+	case components.CommentSubmitMsg:
+		a.commentEditor.SetError(offlineNotice)
+	case components.LinkAddRequestMsg, components.LinkRemoveRequestMsg:
+		a.linkModal.SetError(offlineNotice)
+	case components.AttachmentUploadRequestMsg:
+		a.attachModal.SetError(offlineNotice)
+	case components.DeleteCommentRequestMsg:
+		a.detailView.SetNotice(offlineNotice)
+	default:
+		return false
+	}
+	a.statusMsg = offlineNotice
+	return true
+}
+
+// pollWorkItems re-runs the work item query in the background. Unlike
+// reloadWorkItems it doesn't show as loading, and what changed gets highlighted.
+func (a *App) pollWorkItems() tea.Cmd {
+	if a.loadCancel != nil {
+		a.loadCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.loadCancel = cancel
+	a.loadSeq++
+	if a.wiqlQuery != "" {
+		return loadWIQLCmd(ctx, a.client, a.cache, a.wiqlQuery, a.loadSeq, true)
+	}
+	return loadWorkItemsCmd(ctx, a.client, a.cache, a.filterPanel.FilterState(), a.workItemsPanel.IsTreeMode(), a.loadSeq, true)
+}
+
+// addRefreshChanges highlights how the items of a background refresh differ from
+// the listed ones
+func (a *App) addRefreshChanges(items []models.WorkItem) {
+	changes := models.DiffWorkItems(a.workItems, items)
+	if len(changes) == 0 {
+		return
+	}
+
+	var removed []models.WorkItem
+	for _, item := range a.workItems {
+		if changes[item.ID].Kind == models.ItemRemoved {
+			removed = append(removed, item)
+		}
+	}
+	a.workItemsPanel.AddChanges(changes, removed)
+
+	var added, updated int
+	for _, change := range changes {
+		switch change.Kind {
+		case models.ItemAdded:
+			added++
+		case models.ItemUpdated:
+			updated++
+		}
+	}
+	a.statusMsg = fmt.Sprintf("Refreshed: %d new, %d changed, %d removed", added, updated, len(removed))
+}
+
+// cancelDetailLoad cancels the in-flight full work item load, if any
+func (a *App) cancelDetailLoad() {
+	if a.detailCancel != nil {
+		a.detailCancel()
+		a.detailCancel = nil
+	}
+}
+
+// attachmentContext returns the context of a new attachment transfer, which is
+// cancelled when the attachment modal is closed
+func (a *App) attachmentContext() context.Context {
+	if a.attachCancel != nil {
+		a.attachCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.attachCancel = cancel
+	return ctx
+}
+
+// showHistory switches to the history view of a work item and starts loading it
+func (a *App) showHistory(item models.WorkItem) tea.Cmd {
+	if a.historyCancel != nil {
+		a.historyCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.historyCancel = cancel
+
+	a.historyReturn = a.viewMode
+	a.viewMode = ViewHistory
+	a.historyView.SetItem(&item)
+	a.historyView.SetSize(a.width, a.height)
+	return loadHistoryCmd(ctx, a.client, item.ID)
+}
+
+// nextBulkChunk sends the next batch of the running bulk action, or finishes it with
+// a result summary once all batches are done
+func (a *App) nextBulkChunk() tea.Cmd {
+	run := a.bulk
+	a.bulkModal.SetProgress(len(run.results), run.total)
+	if len(run.pending) > 0 {
+		return bulkUpdateCmd(run.ctx, a.client, run.nextChunk())
+	}
+
+	run.cancel()
+	a.bulk = nil
+	a.bulkModal.SetResults(run.results)
+	if failed := run.failed(); failed > 0 {
+		a.statusMsg = fmt.Sprintf("%s - %d of %d failed", run.label, failed, run.total)
+	} else {
+		a.statusMsg = fmt.Sprintf("%s - %d work item(s) updated", run.label, run.total)
+	}
+	a.workItemsPanel.ClearMarks()
+	return a.reloadWorkItems()
+}
+
+// cancelBulk stops the running bulk action after the batches already sent. Their
+// results are dropped, so the list is reloaded to show what did change.
+func (a *App) cancelBulk() tea.Cmd {
+	run := a.bulk
+	run.cancel()
+	a.bulk = nil
+	a.statusMsg = fmt.Sprintf("%s - cancelled after %d of %d", run.label, len(run.results), run.total)
+	a.workItemsPanel.ClearMarks()
+	return a.reloadWorkItems()
+}
+
+// openView starts the requests of a board, taskboard or planning view being shown
+func (a *App) openView() {
+	a.closeView()
+	a.viewCtx, a.viewCancel = context.WithCancel(context.Background())
+}
+
+// closeView cancels what the closed board, taskboard or planning view still had running
+func (a *App) closeView() {
+	if a.viewCancel != nil {
+		a.viewCancel()
+		a.viewCancel = nil
+	}
+	if a.boardCancel != nil {
+		a.boardCancel()
+		a.boardCancel = nil
+	}
+}
+
+// loadBoards cancels any in-flight board load and loads the team's boards again
+func (a *App) loadBoards() tea.Cmd {
+	if a.boardCancel != nil {
+		a.boardCancel()
+	}
+	ctx, cancel := context.WithCancel(a.viewCtx)
+	a.boardCancel = cancel
+
+	a.boardView.SetLoading()
+	return loadBoardsCmd(ctx, a.client)
+}
+
+// backlogIterationPath returns the iteration path of items not planned in a sprint
+func (a *App) backlogIterationPath() string {
+	return a.client.Project()
+}
+
+// showPlanning switches to sprint planning and loads the backlog and the current and
+// upcoming sprints
+func (a *App) showPlanning() tea.Cmd {
+	if a.planningCancel != nil {
+		a.planningCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.planningCancel = cancel
+
+	var sprints []models.Iteration
+	for _, it := range a.iterations {
+		if !it.IsPast() {
+			sprints = append(sprints, it)
+		}
+	}
+
+	a.viewMode = ViewPlanning
+	a.planningView.SetIterations(sprints, a.backlogIterationPath())
+	a.planningView.SetSize(a.width, a.height)
+
+	// Shares the sequence with work item loads so a stale result is dropped
+	a.loadSeq++
+	a.loading = true
+	area := a.filterPanel.FilterState().GetSelectedArea()
+	return loadPlanningCmd(ctx, a.client, a.planningView.IterationPaths(), area, a.loadSeq)
+}
+
+// loadCycleTime cancels any in-flight cycle time load and starts one for the range
+// the cycle time view shows
+func (a *App) loadCycleTime() tea.Cmd {
+	if a.cycleCancel != nil {
+		a.cycleCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cycleCancel = cancel
+
+	a.cycleTimeView.SetLoading()
+	return loadCycleTimeCmd(ctx, a.client, a.cycleTimeView.Since(), a.filterPanel.FilterState().GetSelectedArea(), a.statesByType)
+}
+
+// loadCycleTimeCmd loads the timings of the backlog items completed since the given day
+func loadCycleTimeCmd(ctx context.Context, client *api.Client, since time.Time, area string, statesByType map[string][]models.WorkItemStateInfo) tea.Cmd {
+	return func() tea.Msg {
+		timings, err := client.GetItemTimingsContext(ctx, area, since, statesByType)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return cycleTimeErrMsg{err: err}
+		}
+		return cycleTimeLoadedMsg{timings: timings}
+	}
+}
+
+// loadFlow cancels any in-flight flow load and starts one for what the flow view shows
+func (a *App) loadFlow() tea.Cmd {
+	if a.flowCancel != nil {
+		a.flowCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.flowCancel = cancel
+
+	start, end, sprint := a.flowView.Scope()
+	a.flowView.SetLoading()
+	return loadFlowCmd(ctx, a.client, sprint, start, end, a.filterPanel.FilterState().GetSelectedArea(), a.statesByType)
+}
+
+// loadVelocity cancels any in-flight velocity load and loads the backlog items of
+// the most recent past sprints
+func (a *App) loadVelocity() tea.Cmd {
+	if a.velocityCancel != nil {
+		a.velocityCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.velocityCancel = cancel
+
+	var past []models.Iteration
+	for _, it := range a.iterations {
+		if it.IsPast() {
+			past = append(past, it)
+		}
+	}
+	sort.SliceStable(past, func(i, j int) bool {
+		return past[i].FinishDate.Before(past[j].FinishDate)
+	})
+	if len(past) > components.MaxVelocitySprints {
+		past = past[len(past)-components.MaxVelocitySprints:]
+	}
+
+	a.velocityView.SetLoading()
+	return loadVelocityCmd(ctx, a.client, past, a.filterPanel.FilterState().GetSelectedArea())
+}
+
+// loadSprintView cancels any in-flight sprint dashboard load and starts one for
+// the sprint the dashboard shows
+func (a *App) loadSprintView() tea.Cmd {
+	if a.sprintCancel != nil {
+		a.sprintCancel()
+	}
+	it := a.sprintView.Iteration()
+	if it == nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.sprintCancel = cancel
+	a.sprintView.SetLoading()
+	return loadSprintViewCmd(ctx, a.client, *it, a.filterPanel.FilterState().GetSelectedArea())
+}
+
+// setCommentCount updates the comment count of a work item in the list
+func (a *App) setCommentCount(id, count int) {
+	for i := range a.workItems {
+		if a.workItems[i].ID == id {
+			a.workItems[i].CommentCount = count
+		}
+	}
+	a.workItemsPanel.UpdateItem(id, func(item *models.WorkItem) {
+		item.CommentCount = count
+	})
+}
+
+func (a *App) updateSelectedItem() {
+	item := a.workItemsPanel.SelectedItem()
+	a.detailsPanel.SetItem(item)
+	a.detailsPanel.SetChange(a.workItemsPanel.SelectedChange())
+}
+
+// Message types
+
+type dataLoadedMsg struct {
+	iterations   []models.Iteration
+	areas        []models.Area
+	statesByType map[string][]models.WorkItemStateInfo
+	teamMembers  []models.TeamMember
+	currentUser  *models.TeamMember
+}
+
+type workItemsLoadedMsg struct {
+	items []models.WorkItem
+	links []models.HierarchyLink // Parent-child links, in tree mode only
+	seq   int                    // Load sequence number, used to drop superseded results
+	poll  bool                   // Background refresh, diffed against the listed items
+	wiql  string                 // WIQL query the items were loaded by, empty for the filters
+
+	// Set if Azure DevOps couldn't be reached and the items are the cached result
+	err      error
+	cachedAt time.Time
+}
+
+// wiqlErrMsg is sent when a WIQL query failed, e.g. on a syntax error
+type wiqlErrMsg struct {
+	err error
+	seq int
+}
+
+// wiqlComposedMsg carries a WIQL query after editing in $EDITOR
+type wiqlComposedMsg struct {
+	text string
+}
+
+// cacheLoadedMsg carries the cached project data, nil if there is none
+type cacheLoadedMsg struct {
+	metadata *cache.Metadata
+}
+
+// refreshTickMsg is sent when the work item list is due for a background refresh
+type refreshTickMsg struct{}
+
 type fullWorkItemLoadedMsg struct {
 	item *models.WorkItem
 }
@@ -542,9 +2081,190 @@ type assignedMsg struct {
 	userName string
 }
 
+type fieldsUpdatedMsg struct {
+	id    int
+	count int
+}
+
+type workItemTypesLoadedMsg struct {
+	types []string
+}
+
+type workItemCreatedMsg struct {
+	item models.WorkItem
+}
+
+// commentsChangedMsg carries a work item's comments after one was added, edited or deleted
+type commentsChangedMsg struct {
+	workItemID int
+	comments   []models.Comment
+	notice     string
+}
+
+type commentErrMsg struct {
+	err error
+}
+
+type historyLoadedMsg struct {
+	id        int
+	revisions []models.WorkItemRevision
+}
+
+type historyErrMsg struct {
+	id  int
+	err error
+}
+
+type iterationChangedMsg struct {
+	id   int
+	name string
+}
+
+type planningLoadedMsg struct {
+	items []models.WorkItem
+	seq   int
+}
+
+type planningErrMsg struct {
+	err error
+	seq int
+}
+
+// planningMovedMsg carries a work item reloaded after it was moved in sprint planning
+type planningMovedMsg struct {
+	item models.WorkItem
+	name string // Name of the sprint, or "Backlog"
+}
+
+type planningMoveErrMsg struct {
+	id  int
+	err error
+}
+
+// cycleTimeLoadedMsg carries the timings of the recently completed items
+type cycleTimeLoadedMsg struct {
+	timings []models.ItemTiming
+}
+
+type cycleTimeErrMsg struct {
+	err error
+}
+
+// flowLoadedMsg carries the counts per state category and day
+type flowLoadedMsg struct {
+	days []models.FlowDay
+}
+
+type flowErrMsg struct {
+	err error
+}
+
+// velocityLoadedMsg carries the past sprints and their backlog items
+type velocityLoadedMsg struct {
+	iterations []models.Iteration
+	items      []models.WorkItem
+}
+
+type velocityErrMsg struct {
+	err error
+}
+
+// sprintViewLoadedMsg carries what the sprint dashboard shows
+type sprintViewLoadedMsg struct {
+	capacity *models.TeamCapacity
+	items    []models.WorkItem
+	burndown []models.BurndownPoint
+}
+
+type sprintViewErrMsg struct {
+	err error
+}
+
+type boardsLoadedMsg struct {
+	boards []models.Board
+}
+
+type boardsErrMsg struct {
+	err error
+}
+
+// boardMovedMsg carries a work item reloaded after its card was moved
+type boardMovedMsg struct {
+	item   models.WorkItem
+	column string
+}
+
+type boardMoveErrMsg struct {
+	id  int
+	err error
+}
+
+// taskMovedMsg carries a task reloaded after its state was changed on the taskboard
+type taskMovedMsg struct {
+	item models.WorkItem
+}
+
+type taskMoveErrMsg struct {
+	id  int
+	err error
+}
+
+// relationsChangedMsg carries a work item reloaded after a link or attachment was added or removed
+type relationsChangedMsg struct {
+	item   *models.WorkItem
+	notice string
+}
+
+type linkErrMsg struct {
+	err error
+}
+
+// attachmentSavedMsg is sent when an attachment was downloaded
+type attachmentSavedMsg struct {
+	path   string
+	opened bool // Opened with the system handler
+}
+
+type attachmentErrMsg struct {
+	err error
+}
+
+// attachmentUploadersMsg carries who added each attachment of a work item
+type attachmentUploadersMsg struct {
+	id        int
+	uploaders map[string]string // By attachment URL
+}
+
+// fieldDefinitionsLoadedMsg carries the field definitions of a work item type.
+// defs is nil if they could not be loaded.
+type fieldDefinitionsLoadedMsg struct {
+	workItemType string
+	defs         []models.FieldDefinition
+}
+
+// updateConflictMsg is sent when an update was rejected because the item changed on the server
+type updateConflictMsg struct {
+	server  models.WorkItem
+	changes []components.FieldChange
+	done    tea.Msg // Sent once the update eventually succeeds
+}
+
 // Commands
 
-func loadDataCmd(client *api.Client) tea.Cmd {
+// loadCacheCmd reads the offline cache
+func loadCacheCmd(store *cache.Store) tea.Cmd {
+	return func() tea.Msg {
+		if err := store.Load(); err != nil {
+			// Not fatal; everything is loaded from the server instead
+			return nil
+		}
+		return cacheLoadedMsg{metadata: store.Metadata()}
+	}
+}
+
+// loadDataCmd loads the project data the filters and modals are built from, and
+// keeps it in the cache
+func loadDataCmd(client *api.Client, store *cache.Store) tea.Cmd {
 	return func() tea.Msg {
 		iterations, err := client.GetIterations()
 		if err != nil {
@@ -564,28 +2284,123 @@ func loadDataCmd(client *api.Client) tea.Cmd {
 			// Non-fatal - we can still work without team members
 			teamMembers = []models.TeamMember{}
 		}
-		return dataLoadedMsg{iterations: iterations, areas: areas, statesByType: statesByType, teamMembers: teamMembers}
+		currentUser, err := client.GetCurrentUser()
+		if err != nil {
+			// Non-fatal - own comments just can't be edited
+			currentUser = nil
+		}
+		store.SetMetadata(cache.Metadata{
+			Iterations:   iterations,
+			Areas:        areas,
+			StatesByType: statesByType,
+			TeamMembers:  teamMembers,
+			CurrentUser:  currentUser,
+			SyncedAt:     time.Now(),
+		})
+		return dataLoadedMsg{iterations: iterations, areas: areas, statesByType: statesByType, teamMembers: teamMembers, currentUser: currentUser}
 	}
 }
 
-func loadWorkItemsCmd(client *api.Client, filterState *models.FilterState) tea.Cmd {
-	return func() tea.Msg {
-		sprint := filterState.GetSelectedSprint()
-		state := filterState.GetSelectedState()
-		assigned := filterState.GetSelectedAssigned()
-		area := filterState.GetSelectedArea()
+// loadWorkItemsCmd queries the work items matching the filters. In tree mode it also
+// loads their descendants and the parent-child links. A flat list that was cached
+// is synced, fetching only what changed since; while offline the cached result is
+// returned instead.
+func loadWorkItemsCmd(ctx context.Context, client *api.Client, store *cache.Store, filterState *models.FilterState, tree bool, seq int, poll bool) tea.Cmd {
+	// Snapshot the filters now; the panel may change them while the query runs
+	sprint := filterState.GetSelectedSprint()
+	state := filterState.GetSelectedState()
+	assigned := filterState.GetSelectedAssigned()
+	area := filterState.GetSelectedArea()
 
-		items, err := client.QueryWorkItems(sprint, state, assigned, area)
+	return func() tea.Msg {
+		key := cache.QueryKey(sprint, state, assigned, area, tree)
+		cached, q, ok := store.Query(key)
+		started := time.Now()
+
+		var items []models.WorkItem
+		var links []models.HierarchyLink
+		var err error
+		switch {
+		case tree:
+			items, links, err = client.QueryWorkItemHierarchyContext(ctx, sprint, state, assigned, area)
+		case ok:
+			byID := make(map[int]models.WorkItem, len(cached))
+			for _, item := range cached {
+				byID[item.ID] = item
+			}
+			items, err = client.SyncWorkItemsContext(ctx, sprint, state, assigned, area, q.SyncedAt, byID)
+		default:
+			items, err = client.QueryWorkItemsContext(ctx, sprint, state, assigned, area)
+		}
+		if ctx.Err() != nil {
+			// Superseded by a newer load
+			return nil
+		}
 		if err != nil {
+			if ok && api.IsOffline(err) {
+				return workItemsLoadedMsg{items: cached, links: q.Links, seq: seq, poll: poll, err: err, cachedAt: q.SyncedAt}
+			}
 			return errMsg{err: err}
 		}
-		return workItemsLoadedMsg{items: items}
+
+		store.PutQuery(key, items, links, started)
+		_ = store.Save() // Only costs the next start a full load
+		return workItemsLoadedMsg{items: items, links: links, seq: seq, poll: poll}
 	}
 }
 
-func loadFullWorkItemCmd(client *api.Client, id int) tea.Cmd {
+// loadWIQLCmd runs a raw WIQL query for the work item list. While offline, the
+// cached result of the query is shown if there is one.
+func loadWIQLCmd(ctx context.Context, client *api.Client, store *cache.Store, query string, seq int, poll bool) tea.Cmd {
 	return func() tea.Msg {
-		item, err := client.GetWorkItem(id)
+		key := cache.WIQLKey(query)
+		started := time.Now()
+
+		items, links, err := client.QueryWIQLContext(ctx, query)
+		if ctx.Err() != nil {
+			// Superseded by a newer load
+			return nil
+		}
+		if err != nil {
+			if cached, q, ok := store.Query(key); ok && api.IsOffline(err) {
+				return workItemsLoadedMsg{items: cached, links: q.Links, seq: seq, poll: poll, wiql: query, err: err, cachedAt: q.SyncedAt}
+			}
+			return wiqlErrMsg{err: err, seq: seq}
+		}
+
+		store.PutQuery(key, items, links, started)
+		_ = store.Save()
+		return workItemsLoadedMsg{items: items, links: links, seq: seq, poll: poll, wiql: query}
+	}
+}
+
+// formatStaleSince formats when cached data was synced, with the date unless it
+// was today
+func formatStaleSince(t time.Time) string {
+	t = t.Local()
+	now := time.Now()
+	if t.Year() == now.Year() && t.YearDay() == now.YearDay() {
+		return t.Format("15:04")
+	}
+	return t.Format("2 Jan 15:04")
+}
+
+// refreshTickCmd waits for the next background refresh, or never if interval is 0
+func refreshTickCmd(interval time.Duration) tea.Cmd {
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return refreshTickMsg{}
+	})
+}
+
+func loadFullWorkItemCmd(ctx context.Context, client *api.Client, id int) tea.Cmd {
+	return func() tea.Msg {
+		item, err := client.GetWorkItemContext(ctx, id)
+		if ctx.Err() != nil {
+			return nil
+		}
 		if err != nil {
 			return errMsg{err: err}
 		}
@@ -593,13 +2408,496 @@ func loadFullWorkItemCmd(client *api.Client, id int) tea.Cmd {
 	}
 }
 
-func updateWorkItemStateCmd(client *api.Client, itemID int, newState string, filterState *models.FilterState) tea.Cmd {
+func loadHistoryCmd(ctx context.Context, client *api.Client, id int) tea.Cmd {
 	return func() tea.Msg {
-		err := client.UpdateWorkItemState(itemID, newState)
+		revisions, err := client.GetWorkItemHistoryContext(ctx, id)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return historyErrMsg{id: id, err: err}
+		}
+		return historyLoadedMsg{id: id, revisions: revisions}
+	}
+}
+
+func loadPlanningCmd(ctx context.Context, client *api.Client, iterationPaths []string, area string, seq int) tea.Cmd {
+	return func() tea.Msg {
+		items, err := client.QueryPlanningItemsContext(ctx, iterationPaths, area)
+		if err != nil {
+			return planningErrMsg{err: err, seq: seq}
+		}
+		return planningLoadedMsg{items: items, seq: seq}
+	}
+}
+
+// planningMoveCmd changes the iteration of a work item and fetches it again, so its
+// new revision is known if it's moved once more
+func planningMoveCmd(ctx context.Context, client *api.Client, req components.MoveToSprintRequestMsg) tea.Cmd {
+	return func() tea.Msg {
+		fields := map[string]interface{}{"System.IterationPath": req.IterationPath}
+		if err := client.UpdateFieldsContext(ctx, req.Item.ID, req.Item.Rev, fields); err != nil {
+			if ctx.Err() != nil {
+				// Planning was closed; the list is reloaded anyway
+				return nil
+			}
+			changes := []components.FieldChange{{
+				Field: "System.IterationPath",
+				Label: "Iteration",
+				Base:  req.Item.IterationPath,
+				Ours:  req.IterationPath,
+				Value: req.IterationPath,
+			}}
+			if msg := conflictMsg(ctx, client, req.Item.ID, err, changes, components.ReloadPlanningMsg{}); msg != nil {
+				return msg
+			}
+			return planningMoveErrMsg{id: req.Item.ID, err: err}
+		}
+		items, err := client.GetWorkItemsContext(ctx, []string{strconv.Itoa(req.Item.ID)})
+		if err != nil || len(items) == 0 {
+			// The move succeeded; keep the old revision rather than failing it
+			item := req.Item
+			item.IterationPath = req.IterationPath
+			return planningMovedMsg{item: item, name: req.IterationName}
+		}
+		return planningMovedMsg{item: items[0], name: req.IterationName}
+	}
+}
+
+// loadSprintViewCmd loads the team capacity and the items of a sprint, and builds
+// its burndown from the history of their remaining work
+func loadSprintViewCmd(ctx context.Context, client *api.Client, it models.Iteration, area string) tea.Cmd {
+	return func() tea.Msg {
+		fail := func(err error) tea.Msg {
+			if ctx.Err() != nil {
+				// Superseded by another sprint or closed
+				return nil
+			}
+			return sprintViewErrMsg{err: err}
+		}
+
+		capacity, err := client.GetTeamCapacityContext(ctx, it.ID)
+		if err != nil {
+			return fail(err)
+		}
+		items, err := client.QueryWorkItemsContext(ctx, it.Path, "all", "all", area)
+		if err != nil {
+			return fail(err)
+		}
+
+		ids := make([]int, len(items))
+		for i, item := range items {
+			ids[i] = item.ID
+		}
+		timelines, err := client.GetFieldTimelinesContext(ctx, ids, []string{
+			"System.IterationPath",
+			"Microsoft.VSTS.Scheduling.RemainingWork",
+		})
+		if err != nil {
+			return fail(err)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		burndown := models.Burndown(timelines, it.Path, capacity.SprintDays(&it), time.Now())
+		return sprintViewLoadedMsg{capacity: capacity, items: items, burndown: burndown}
+	}
+}
+
+// loadFlowCmd loads the backlog items of a sprint, or those active between start and
+// end, and counts them per state category and day using their state history
+func loadFlowCmd(ctx context.Context, client *api.Client, sprint *models.Iteration, start, end time.Time, area string, statesByType map[string][]models.WorkItemStateInfo) tea.Cmd {
+	var sprintPath string
+	if sprint != nil {
+		sprintPath = sprint.Path
+	}
+
+	return func() tea.Msg {
+		fail := func(err error) tea.Msg {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return flowErrMsg{err: err}
+		}
+
+		var items []models.WorkItem
+		var err error
+		if sprintPath != "" {
+			items, err = client.QueryPlanningItemsContext(ctx, []string{sprintPath}, area)
+		} else {
+			items, err = client.QueryFlowItemsContext(ctx, area, start, models.AllStatesInCategory(statesByType, "Completed"))
+		}
+		if err != nil {
+			return fail(err)
+		}
+
+		// Items unchanged since the start had their current state all along
+		since := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+		var ids []int
+		for _, item := range items {
+			if !item.ChangedDate.Before(since) {
+				ids = append(ids, item.ID)
+			}
+		}
+		timelines, err := client.GetFieldTimelinesContext(ctx, ids, []string{"System.State"})
+		if err != nil {
+			return fail(err)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		return flowLoadedMsg{days: models.CumulativeFlow(items, timelines, statesByType, start, end)}
+	}
+}
+
+func loadVelocityCmd(ctx context.Context, client *api.Client, iterations []models.Iteration, area string) tea.Cmd {
+	return func() tea.Msg {
+		paths := make([]string, len(iterations))
+		for i, it := range iterations {
+			paths[i] = it.Path
+		}
+		items, err := client.QueryPlanningItemsContext(ctx, paths, area)
+		if ctx.Err() != nil {
+			// Superseded by another load or closed
+			return nil
+		}
+		if err != nil {
+			return velocityErrMsg{err: err}
+		}
+		return velocityLoadedMsg{iterations: iterations, items: items}
+	}
+}
+
+// loadBoardsCmd loads the team's boards with their columns and swimlanes
+func loadBoardsCmd(ctx context.Context, client *api.Client) tea.Cmd {
+	return func() tea.Msg {
+		boards, err := client.GetBoardsContext(ctx)
+		if ctx.Err() != nil {
+			// Superseded by another load or closed
+			return nil
+		}
+		if err != nil {
+			return boardsErrMsg{err: err}
+		}
+		return boardsLoadedMsg{boards: boards}
+	}
+}
+
+// boardMoveCmd moves a card and fetches the item again for its new revision and state
+func boardMoveCmd(ctx context.Context, client *api.Client, req components.BoardMoveRequestMsg) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.MoveBoardCardContext(ctx, &req.Board, &req.Item, req.Column, req.Done); err != nil {
+			if ctx.Err() != nil {
+				// The board was closed; the list is reloaded anyway
+				return nil
+			}
+			changes := boardMoveChanges(req)
+			if msg := conflictMsg(ctx, client, req.Item.ID, err, changes, components.ReloadBoardMsg{}); msg != nil {
+				return msg
+			}
+			return boardMoveErrMsg{id: req.Item.ID, err: err}
+		}
+		column := req.Board.Columns[req.Column].Name
+		if req.Done {
+			column += " (Done)"
+		}
+		items, err := client.GetWorkItemsContext(ctx, []string{strconv.Itoa(req.Item.ID)})
+		if err != nil || len(items) == 0 {
+			item := req.Item
+			item.BoardColumn = req.Board.Columns[req.Column].Name
+			item.BoardColumnDone = req.Done
+			return boardMovedMsg{item: item, column: column}
+		}
+		return boardMovedMsg{item: items[0], column: column}
+	}
+}
+
+// taskMoveCmd changes the state of a task and fetches it again for its new revision
+func taskMoveCmd(ctx context.Context, client *api.Client, req components.TaskMoveRequestMsg) tea.Cmd {
+	return func() tea.Msg {
+		fields := map[string]interface{}{"System.State": req.State}
+		if err := client.UpdateFieldsContext(ctx, req.Item.ID, req.Item.Rev, fields); err != nil {
+			if ctx.Err() != nil {
+				// The taskboard was closed; the list is reloaded anyway
+				return nil
+			}
+			changes := []components.FieldChange{{
+				Field: "System.State",
+				Label: "State",
+				Base:  string(req.Item.State),
+				Ours:  req.State,
+				Value: req.State,
+			}}
+			if msg := conflictMsg(ctx, client, req.Item.ID, err, changes, components.ReloadTaskboardMsg{}); msg != nil {
+				return msg
+			}
+			return taskMoveErrMsg{id: req.Item.ID, err: err}
+		}
+		items, err := client.GetWorkItemsContext(ctx, []string{strconv.Itoa(req.Item.ID)})
+		if err != nil || len(items) == 0 {
+			item := req.Item
+			item.State = models.WorkItemState(req.State)
+			return taskMovedMsg{item: item}
+		}
+		return taskMovedMsg{item: items[0]}
+	}
+}
+
+// boardMoveChanges lists the fields MoveBoardCard sets, for the conflict modal
+func boardMoveChanges(req components.BoardMoveRequestMsg) []components.FieldChange {
+	col := req.Board.Columns[req.Column]
+	changes := []components.FieldChange{{
+		Field: req.Board.ColumnField,
+		Label: "Column",
+		Base:  req.Item.BoardColumn,
+		Ours:  col.Name,
+		Value: col.Name,
+	}}
+	if req.Board.DoneField != "" {
+		done := col.IsSplit && req.Done
+		changes = append(changes, components.FieldChange{
+			Field: req.Board.DoneField,
+			Label: "Done",
+			Base:  strconv.FormatBool(req.Item.BoardColumnDone),
+			Ours:  strconv.FormatBool(done),
+			Value: done,
+		})
+	}
+	if state, ok := col.StateMappings[string(req.Item.Type)]; ok && state != string(req.Item.State) {
+		changes = append(changes, components.FieldChange{
+			Field: "System.State",
+			Label: "State",
+			Base:  string(req.Item.State),
+			Ours:  state,
+			Value: state,
+		})
+	}
+	return changes
+}
+
+func updateWorkItemStateCmd(client *api.Client, item models.WorkItem, newState string) tea.Cmd {
+	changes := []components.FieldChange{{
+		Field: "System.State",
+		Label: "State",
+		Base:  string(item.State),
+		Ours:  newState,
+		Value: newState,
+	}}
+	return updateFieldsCmd(client, item.ID, item.Rev, changes, stateChangedMsg{newState: newState})
+}
+
+// updateFieldsCmd patches fields against the given revision. On a revision conflict it
+// fetches the server's current item so the user can resolve the conflict.
+func updateFieldsCmd(client *api.Client, id, rev int, changes []components.FieldChange, done tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		fields := make(map[string]interface{}, len(changes))
+		for _, c := range changes {
+			fields[c.Field] = c.Value
+		}
+
+		err := client.UpdateFieldsContext(ctx, id, rev, fields)
+		if err != nil {
+			if msg := conflictMsg(ctx, client, id, err, changes, done); msg != nil {
+				return msg
+			}
+			var conflict *api.RevisionConflictError
+			if errors.As(err, &conflict) {
+				return errMsg{err: err}
+			}
+			return updateErrMsg{id: id, changes: changes, err: err}
+		}
+		return done
+	}
+}
+
+// conflictMsg fetches the server's current item if err is a revision conflict, so
+// the user can resolve it. It returns nil for other errors or if the item can't be
+// loaded.
+func conflictMsg(ctx context.Context, client *api.Client, id int, err error, changes []components.FieldChange, done tea.Msg) tea.Msg {
+	var conflict *api.RevisionConflictError
+	if !errors.As(err, &conflict) {
+		return nil
+	}
+	items, loadErr := client.GetWorkItemsContext(ctx, []string{strconv.Itoa(id)})
+	if loadErr != nil || len(items) == 0 {
+		return nil
+	}
+	return updateConflictMsg{server: items[0], changes: changes, done: done}
+}
+
+func loadFieldDefinitionsCmd(client *api.Client, workItemType string) tea.Cmd {
+	return func() tea.Msg {
+		defs, err := client.GetWorkItemTypeFields(workItemType)
+		if err != nil {
+			// Non-fatal - the editor falls back to built-in field types
+			return fieldDefinitionsLoadedMsg{workItemType: workItemType}
+		}
+		return fieldDefinitionsLoadedMsg{workItemType: workItemType, defs: defs}
+	}
+}
+
+func loadWorkItemTypesCmd(client *api.Client) tea.Cmd {
+	return func() tea.Msg {
+		types, err := client.GetWorkItemTypes()
 		if err != nil {
 			return errMsg{err: err}
 		}
-		return stateChangedMsg{newState: newState}
+		return workItemTypesLoadedMsg{types: types}
+	}
+}
+
+func createWorkItemCmd(client *api.Client, req components.CreateWorkItemRequestMsg) tea.Cmd {
+	fields := map[string]interface{}{
+		"System.Title": req.Title,
+	}
+	if req.IterationPath != "" {
+		fields["System.IterationPath"] = req.IterationPath
+	}
+	if req.AreaPath != "" {
+		fields["System.AreaPath"] = req.AreaPath
+	}
+
+	parentID := 0
+	parentTitle := ""
+	if req.Parent != nil {
+		parentID = req.Parent.ID
+		parentTitle = req.Parent.Title
+	}
+
+	return func() tea.Msg {
+		item, err := client.CreateWorkItem(req.Type, fields, parentID)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		item.ParentTitle = parentTitle
+		return workItemCreatedMsg{item: *item}
+	}
+}
+
+func saveCommentCmd(client *api.Client, req components.CommentSubmitMsg) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		notice := "Comment added"
+		if req.CommentID == 0 {
+			_, err = client.AddComment(req.WorkItemID, req.Text)
+		} else {
+			_, err = client.UpdateComment(req.WorkItemID, req.CommentID, req.Text)
+			notice = "Comment updated"
+		}
+		if err != nil {
+			return commentErrMsg{err: err}
+		}
+		return reloadCommentsMsg(client, req.WorkItemID, notice)
+	}
+}
+
+func deleteCommentCmd(client *api.Client, workItemID, commentID int) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.DeleteComment(workItemID, commentID); err != nil {
+			return commentErrMsg{err: err}
+		}
+		return reloadCommentsMsg(client, workItemID, "Comment deleted")
+	}
+}
+
+// reloadCommentsMsg fetches the current comments of a work item after a change
+func reloadCommentsMsg(client *api.Client, workItemID int, notice string) tea.Msg {
+	comments, err := client.GetWorkItemComments(workItemID)
+	if err != nil {
+		return commentErrMsg{err: err}
+	}
+	return commentsChangedMsg{workItemID: workItemID, comments: comments, notice: notice}
+}
+
+func addLinkCmd(client *api.Client, req components.LinkAddRequestMsg) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.AddLink(req.ItemID, req.LinkType, req.TargetID); err != nil {
+			return linkErrMsg{err: err}
+		}
+		return reloadLinksMsg(client, req.ItemID, fmt.Sprintf("Linked #%d as %s", req.TargetID, req.LinkType))
+	}
+}
+
+func removeLinkCmd(client *api.Client, req components.LinkRemoveRequestMsg) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.RemoveLink(req.ItemID, req.LinkType, req.TargetID); err != nil {
+			return linkErrMsg{err: err}
+		}
+		return reloadLinksMsg(client, req.ItemID, fmt.Sprintf("Removed %s link to #%d", req.LinkType, req.TargetID))
+	}
+}
+
+// reloadLinksMsg fetches a work item again so its RelatedLinks and ChildIDs reflect a link change
+func reloadLinksMsg(client *api.Client, id int, notice string) tea.Msg {
+	item, err := client.GetWorkItem(id)
+	if err != nil {
+		return linkErrMsg{err: err}
+	}
+	return relationsChangedMsg{item: item, notice: notice}
+}
+
+// downloadAttachmentCmd saves an attachment into dir, or into a temporary directory
+// and opens it with the system handler
+func downloadAttachmentCmd(ctx context.Context, client *api.Client, att models.Attachment, dir string, open bool) tea.Cmd {
+	if open {
+		dir = filepath.Join(os.TempDir(), "devops-tui")
+	}
+	return func() tea.Msg {
+		path, err := client.SaveAttachmentContext(ctx, att, dir)
+		if ctx.Err() != nil {
+			return attachmentErrMsg{err: ctx.Err()}
+		}
+		if err != nil {
+			return attachmentErrMsg{err: err}
+		}
+		if open {
+			if err := browser.Open(path); err != nil {
+				return attachmentErrMsg{err: fmt.Errorf("opening %s: %w", path, err)}
+			}
+		}
+		return attachmentSavedMsg{path: path, opened: open}
+	}
+}
+
+// loadAttachmentUploadersCmd looks up who added the attachments of a work item, which
+// takes its whole history, so it's done after the item is shown
+func loadAttachmentUploadersCmd(client *api.Client, item models.WorkItem) tea.Cmd {
+	if len(item.Attachments) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		uploaders, err := client.GetAttachmentUploaders(item.ID)
+		if err != nil {
+			// Non-fatal - attachments are listed without their uploaders
+			return nil
+		}
+		return attachmentUploadersMsg{id: item.ID, uploaders: uploaders}
+	}
+}
+
+func uploadAttachmentCmd(ctx context.Context, client *api.Client, id int, path string) tea.Cmd {
+	// Expand ~ like a shell would
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+	return func() tea.Msg {
+		err := client.UploadAttachmentContext(ctx, id, path)
+		if ctx.Err() != nil {
+			return attachmentErrMsg{err: ctx.Err()}
+		}
+		if err != nil {
+			return attachmentErrMsg{err: err}
+		}
+		item, err := client.GetWorkItem(id)
+		if err != nil {
+			return attachmentErrMsg{err: err}
+		}
+		return relationsChangedMsg{item: item, notice: "Uploaded " + filepath.Base(path)}
 	}
 }
 
@@ -619,12 +2917,13 @@ func createBranchCmd(branchName string) tea.Cmd {
 	}
 }
 
-func assignWorkItemCmd(client *api.Client, itemID int, userEmail, userName string, filterState *models.FilterState) tea.Cmd {
-	return func() tea.Msg {
-		err := client.AssignWorkItem(itemID, userEmail)
-		if err != nil {
-			return errMsg{err: err}
-		}
-		return assignedMsg{userName: userName}
-	}
+func assignWorkItemCmd(client *api.Client, item models.WorkItem, userEmail, userName string) tea.Cmd {
+	changes := []components.FieldChange{{
+		Field: "System.AssignedTo",
+		Label: "Assigned To",
+		Base:  item.AssignedTo,
+		Ours:  userName,
+		Value: userEmail,
+	}}
+	return updateFieldsCmd(client, item.ID, item.Rev, changes, assignedMsg{userName: userName})
 }
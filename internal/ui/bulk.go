@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/samuelenocsson/devops-tui/internal/api"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/components"
+)
+
+// bulkChunkSize is the number of updates sent per batch request. Smaller than
+// api.MaxBatchSize so progress can be shown on large selections.
+const bulkChunkSize = 20
+
+// bulkRun tracks a bulk action while its batches are being applied
+type bulkRun struct {
+	label   string // Action and value, for the status line
+	pending []api.BatchUpdate
+	titles  map[int]string
+	results []components.BulkResult
+	total   int
+
+	// ctx is cancelled when the user stops the run; batches already sent still apply
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// newBulkRun builds the updates for a bulk action. Items the action wouldn't change,
+// such as adding a tag an item already has, succeed without a request.
+func newBulkRun(req components.BulkActionRequestMsg) *bulkRun {
+	run := &bulkRun{
+		label:  req.Action.String() + ": " + req.Label,
+		titles: make(map[int]string, len(req.Items)),
+		total:  len(req.Items),
+	}
+	run.ctx, run.cancel = context.WithCancel(context.Background())
+
+	for _, item := range req.Items {
+		run.titles[item.ID] = item.Title
+
+		var field string
+		var value interface{} = req.Value
+		switch req.Action {
+		case components.BulkChangeState:
+			field = "System.State"
+		case components.BulkAssign:
+			field = "System.AssignedTo"
+		case components.BulkMoveIteration:
+			field = "System.IterationPath"
+		case components.BulkMoveArea:
+			field = "System.AreaPath"
+		case components.BulkAddTag, components.BulkRemoveTag:
+			tags, changed := changeTags(item.Tags, req.Value, req.Action == components.BulkAddTag)
+			if !changed {
+				run.results = append(run.results, components.BulkResult{ID: item.ID, Title: item.Title})
+				continue
+			}
+			field = "System.Tags"
+			value = strings.Join(tags, "; ")
+		}
+
+		run.pending = append(run.pending, api.BatchUpdate{
+			ID:     item.ID,
+			Rev:    item.Rev,
+			Fields: map[string]interface{}{field: value},
+		})
+	}
+	return run
+}
+
+// changeTags adds or removes a tag, ignoring case like Azure DevOps does.
+// Returns false if the tags are unchanged.
+func changeTags(tags []string, tag string, add bool) ([]string, bool) {
+	var result []string
+	found := false
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			found = true
+			if !add {
+				continue
+			}
+		}
+		result = append(result, t)
+	}
+	if add {
+		if found {
+			return tags, false
+		}
+		return append(result, tag), true
+	}
+	return result, found
+}
+
+// knownTags returns the distinct tags of the given work items, sorted
+func knownTags(items []models.WorkItem) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, item := range items {
+		for _, tag := range item.Tags {
+			if !seen[strings.ToLower(tag)] {
+				seen[strings.ToLower(tag)] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// nextChunk removes the next batch of updates from the pending ones
+func (r *bulkRun) nextChunk() []api.BatchUpdate {
+	n := bulkChunkSize
+	if n > len(r.pending) {
+		n = len(r.pending)
+	}
+	chunk := r.pending[:n]
+	r.pending = r.pending[n:]
+	return chunk
+}
+
+// addResults records the outcome of a batch. If the batch request itself failed,
+// all of its updates are reported with that error.
+func (r *bulkRun) addResults(chunk []api.BatchUpdate, results []api.BatchResult, err error) {
+	if err != nil {
+		for _, u := range chunk {
+			r.results = append(r.results, components.BulkResult{ID: u.ID, Title: r.titles[u.ID], Err: describeError(err)})
+		}
+		return
+	}
+	for _, res := range results {
+		result := components.BulkResult{ID: res.ID, Title: r.titles[res.ID]}
+		if res.Err != nil {
+			result.Err = describeError(res.Err)
+		}
+		r.results = append(r.results, result)
+	}
+}
+
+// failed returns the number of work items that couldn't be updated
+func (r *bulkRun) failed() int {
+	n := 0
+	for _, res := range r.results {
+		if res.Err != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// bulkUpdateCmd applies one batch of a bulk action
+func bulkUpdateCmd(ctx context.Context, client *api.Client, chunk []api.BatchUpdate) tea.Cmd {
+	return func() tea.Msg {
+		results, err := client.UpdateWorkItemsBatchContext(ctx, chunk)
+		return bulkChunkDoneMsg{chunk: chunk, results: results, err: err}
+	}
+}
+
+// bulkChunkDoneMsg carries the results of one batch of a bulk action
+type bulkChunkDoneMsg struct {
+	chunk   []api.BatchUpdate
+	results []api.BatchResult
+	err     error
+}
@@ -0,0 +1,263 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// AttachmentModal is a modal for downloading and uploading work item attachments
+type AttachmentModal struct {
+	visible   bool
+	item      *models.WorkItem
+	cursor    int
+	uploading bool
+	input     textinput.Model
+	busy      string // In-progress operation, e.g. "Downloading..."
+	notice    string
+	errMsg    string
+	styles    theme.Styles
+	keys      theme.KeyMap
+	width     int
+	height    int
+}
+
+// NewAttachmentModal creates a new attachment modal
+func NewAttachmentModal(styles theme.Styles, keys theme.KeyMap) AttachmentModal {
+	ti := textinput.New()
+	ti.Placeholder = "Path of the file to upload..."
+	ti.CharLimit = 500
+	ti.Width = 56
+
+	return AttachmentModal{
+		input:  ti,
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the modal
+func (m AttachmentModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m AttachmentModal) Update(msg tea.Msg) (AttachmentModal, tea.Cmd) {
+	if !m.visible || m.item == nil {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.uploading {
+		return m.updateUpload(keyMsg)
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		m.visible = false
+		return m, func() tea.Msg { return ModalClosedMsg{} }
+	case key.Matches(keyMsg, m.keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, m.keys.Down):
+		if m.cursor < len(m.item.Attachments)-1 {
+			m.cursor++
+		}
+	case keyMsg.String() == "enter", keyMsg.String() == "d", keyMsg.String() == "o":
+		if m.busy != "" || m.cursor >= len(m.item.Attachments) {
+			return m, nil
+		}
+		open := keyMsg.String() == "o"
+		m.busy = "Downloading..."
+		m.notice, m.errMsg = "", ""
+		req := AttachmentDownloadRequestMsg{Attachment: m.item.Attachments[m.cursor], Open: open}
+		return m, func() tea.Msg { return req }
+	case keyMsg.String() == "u":
+		if m.busy != "" {
+			return m, nil
+		}
+		m.uploading = true
+		m.notice, m.errMsg = "", ""
+		m.input.SetValue("")
+		m.input.Focus()
+		return m, textinput.Blink
+	}
+
+	return m, nil
+}
+
+// updateUpload handles keys while entering the path of a file to upload
+func (m AttachmentModal) updateUpload(msg tea.KeyMsg) (AttachmentModal, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.uploading = false
+		m.input.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		path := strings.TrimSpace(m.input.Value())
+		if path == "" {
+			m.errMsg = "Enter the path of a file"
+			return m, nil
+		}
+		m.uploading = false
+		m.input.Blur()
+		m.busy = "Uploading..."
+		m.errMsg = ""
+		req := AttachmentUploadRequestMsg{ItemID: m.item.ID, Path: path}
+		return m, func() tea.Msg { return req }
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// View renders the modal
+func (m AttachmentModal) View() string {
+	if !m.visible || m.item == nil {
+		return ""
+	}
+
+	modalWidth := 76
+
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Render("Attachments")
+	itemInfo := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF")).
+		Render("#" + itoa(m.item.ID) + " " + truncateStr(m.item.Title, 56))
+	b.WriteString(title + "\n")
+	b.WriteString(itemInfo + "\n\n")
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+
+	if len(m.item.Attachments) == 0 {
+		b.WriteString(mutedStyle.Render("No attachments") + "\n")
+	}
+	for i, att := range m.item.Attachments {
+		line := padRight(truncateStr(att.Name, 34), 35) + padRight(att.FormattedSize(), 10) +
+			truncateStr(att.UploadedBy, 20)
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render("▸ "+line) + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if m.uploading {
+		b.WriteString("Upload file:\n" + m.input.View() + "\n\n")
+		b.WriteString(mutedStyle.Render("Enter: upload  Esc: cancel"))
+	} else {
+		b.WriteString(mutedStyle.Render("Enter/d: download  o: open  u: upload  Esc: close"))
+	}
+
+	switch {
+	case m.busy != "":
+		b.WriteString("\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render(m.busy))
+	case m.errMsg != "":
+		b.WriteString("\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(m.errMsg))
+	case m.notice != "":
+		b.WriteString("\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Render(m.notice))
+	}
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Background(lipgloss.Color("#1F2937"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(b.String()))
+}
+
+// SetItem sets the work item whose attachments are shown
+func (m *AttachmentModal) SetItem(item *models.WorkItem) {
+	// Own the attachments, which SetUploaders changes
+	copied := *item
+	copied.Attachments = append([]models.Attachment(nil), item.Attachments...)
+	m.item = &copied
+	if m.cursor >= len(item.Attachments) {
+		m.cursor = 0
+	}
+	m.uploading = false
+	m.busy = ""
+	m.input.Blur()
+}
+
+// SetUploaders sets who added each attachment, keyed by attachment URL
+func (m *AttachmentModal) SetUploaders(uploaders map[string]string) {
+	if m.item == nil {
+		return
+	}
+	for i := range m.item.Attachments {
+		m.item.Attachments[i].UploadedBy = uploaders[m.item.Attachments[i].URL]
+	}
+}
+
+// SetNotice ends the running operation with a message
+func (m *AttachmentModal) SetNotice(notice string) {
+	m.busy = ""
+	m.errMsg = ""
+	m.notice = notice
+}
+
+// SetError ends the running operation with an error
+func (m *AttachmentModal) SetError(err string) {
+	m.busy = ""
+	m.notice = ""
+	m.errMsg = err
+}
+
+// ItemID returns the ID of the work item whose attachments are shown, or 0 if none
+func (m *AttachmentModal) ItemID() int {
+	if m.item == nil {
+		return 0
+	}
+	return m.item.ID
+}
+
+// SetVisible sets the visibility
+func (m *AttachmentModal) SetVisible(visible bool) {
+	m.visible = visible
+	if !visible {
+		m.uploading = false
+		m.notice = ""
+		m.errMsg = ""
+		m.input.Blur()
+	}
+}
+
+// IsVisible returns whether the modal is visible
+func (m *AttachmentModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal container size
+func (m *AttachmentModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// AttachmentDownloadRequestMsg is sent when the user downloads an attachment
+type AttachmentDownloadRequestMsg struct {
+	Attachment models.Attachment
+	Open       bool // Open with the system handler instead of saving to the download directory
+}
+
+// AttachmentUploadRequestMsg is sent when the user uploads a file
+type AttachmentUploadRequestMsg struct {
+	ItemID int
+	Path   string
+}
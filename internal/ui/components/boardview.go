@@ -0,0 +1,569 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// boardMinColumnWidth is the narrowest a board column is drawn; columns that don't
+// fit scroll horizontally
+const boardMinColumnWidth = 22
+
+// BoardView is the fullscreen Kanban board of the listed work items
+type BoardView struct {
+	boards    []models.Board
+	board     int               // Index of the board shown
+	items     []models.WorkItem // Listed work items, cards are those on the board
+	col       int               // Selected column
+	card      int               // Selected card within the column
+	colOffset int               // First visible column
+	pending   map[int]models.WorkItem
+	loading   bool
+	notice    string
+	errMsg    string
+	styles    theme.Styles
+	keys      theme.KeyMap
+	width     int
+	height    int
+}
+
+// NewBoardView creates a new board view
+func NewBoardView(styles theme.Styles, keys theme.KeyMap) BoardView {
+	return BoardView{
+		pending: make(map[int]models.WorkItem),
+		styles:  styles,
+		keys:    keys,
+	}
+}
+
+// Init initializes the board view
+func (b BoardView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the board view
+func (b *BoardView) Update(msg tea.Msg) (*BoardView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return b, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, b.keys.Back):
+		return b, func() tea.Msg { return CloseBoardMsg{} }
+	case key.Matches(keyMsg, b.keys.Quit) && keyMsg.String() == "q":
+		return b, func() tea.Msg { return CloseBoardMsg{} }
+	case key.Matches(keyMsg, b.keys.Refresh):
+		return b, func() tea.Msg { return ReloadBoardMsg{} }
+	case key.Matches(keyMsg, b.keys.Left):
+		b.selectColumn(b.col - 1)
+	case key.Matches(keyMsg, b.keys.Right):
+		b.selectColumn(b.col + 1)
+	case key.Matches(keyMsg, b.keys.Up):
+		b.selectCard(b.card - 1)
+	case key.Matches(keyMsg, b.keys.Down):
+		b.selectCard(b.card + 1)
+	case key.Matches(keyMsg, b.keys.Top):
+		b.selectCard(0)
+	case key.Matches(keyMsg, b.keys.Bottom):
+		b.selectCard(len(b.cards(b.col)) - 1)
+	case keyMsg.String() == "]":
+		b.selectBoard(b.board + 1)
+	case keyMsg.String() == "[":
+		b.selectBoard(b.board - 1)
+	case keyMsg.String() == ">":
+		return b, b.moveCard(1)
+	case keyMsg.String() == "<":
+		return b, b.moveCard(-1)
+	case keyMsg.String() == "d":
+		return b, b.toggleDone()
+	case key.Matches(keyMsg, b.keys.Open):
+		if item := b.selectedCard(); item != nil {
+			open := *item
+			return b, func() tea.Msg { return OpenWorkItemMsg{Item: open} }
+		}
+	}
+
+	return b, nil
+}
+
+// current returns the board shown, or nil if none are loaded
+func (b *BoardView) current() *models.Board {
+	if b.board >= len(b.boards) {
+		return nil
+	}
+	return &b.boards[b.board]
+}
+
+// cards returns the items in a column: by swimlane, then Doing before Done
+func (b *BoardView) cards(col int) []models.WorkItem {
+	board := b.current()
+	if board == nil {
+		return nil
+	}
+	var cards []models.WorkItem
+	for _, item := range b.items {
+		if board.ColumnOf(&item) == col {
+			cards = append(cards, item)
+		}
+	}
+	sort.SliceStable(cards, func(i, j int) bool {
+		ri, rj := board.RowOf(&cards[i]), board.RowOf(&cards[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return !cards[i].BoardColumnDone && cards[j].BoardColumnDone
+	})
+	return cards
+}
+
+// selectedCard returns the selected item, or nil if the column is empty
+func (b *BoardView) selectedCard() *models.WorkItem {
+	cards := b.cards(b.col)
+	if b.card >= len(cards) {
+		return nil
+	}
+	return &cards[b.card]
+}
+
+// selectBoard switches to another backlog level's board
+func (b *BoardView) selectBoard(index int) {
+	if index < 0 || index >= len(b.boards) {
+		return
+	}
+	b.board = index
+	b.col, b.card, b.colOffset = 0, 0, 0
+}
+
+// selectColumn moves the column cursor, scrolling columns into view
+func (b *BoardView) selectColumn(index int) {
+	board := b.current()
+	if board == nil || index < 0 || index >= len(board.Columns) {
+		return
+	}
+	b.col = index
+	b.selectCard(b.card)
+
+	visible := b.visibleColumns()
+	if b.col < b.colOffset {
+		b.colOffset = b.col
+	} else if b.col >= b.colOffset+visible {
+		b.colOffset = b.col - visible + 1
+	}
+}
+
+// selectCard moves the card cursor, keeping it within the column
+func (b *BoardView) selectCard(index int) {
+	n := len(b.cards(b.col))
+	if index >= n {
+		index = n - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	b.card = index
+}
+
+// visibleColumns is the number of columns that fit the width
+func (b *BoardView) visibleColumns() int {
+	board := b.current()
+	if board == nil {
+		return 1
+	}
+	n := (b.width + 1) / (boardMinColumnWidth + 1)
+	if n > len(board.Columns) {
+		n = len(board.Columns)
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// moveCard moves the selected card to the next column that accepts its type, in
+// direction dir
+func (b *BoardView) moveCard(dir int) tea.Cmd {
+	board := b.current()
+	item := b.selectedCard()
+	if board == nil || item == nil {
+		return nil
+	}
+	for col := b.col + dir; col >= 0 && col < len(board.Columns); col += dir {
+		if board.Columns[col].Accepts(item.Type) {
+			cmd := b.requestMove(*item, col, false)
+			b.selectColumn(col)
+			b.selectCard(b.indexOf(item.ID))
+			return cmd
+		}
+	}
+	return nil
+}
+
+// toggleDone moves the selected card between Doing and Done of a split column
+func (b *BoardView) toggleDone() tea.Cmd {
+	board := b.current()
+	item := b.selectedCard()
+	if board == nil || item == nil || !board.Columns[b.col].IsSplit {
+		return nil
+	}
+	cmd := b.requestMove(*item, b.col, !item.BoardColumnDone)
+	b.selectCard(b.indexOf(item.ID))
+	return cmd
+}
+
+// indexOf returns the position of a card in the selected column
+func (b *BoardView) indexOf(id int) int {
+	for i, card := range b.cards(b.col) {
+		if card.ID == id {
+			return i
+		}
+	}
+	return 0
+}
+
+// requestMove moves a card right away and requests the change; it is moved back
+// if the update fails
+func (b *BoardView) requestMove(item models.WorkItem, col int, done bool) tea.Cmd {
+	if _, busy := b.pending[item.ID]; busy {
+		return nil
+	}
+	board := b.current()
+	column := board.Columns[col]
+
+	b.pending[item.ID] = item
+	b.notice, b.errMsg = "", ""
+	for i := range b.items {
+		if b.items[i].ID == item.ID {
+			b.items[i].BoardColumn = column.Name
+			b.items[i].BoardColumnDone = done
+			if state, ok := column.StateMappings[string(item.Type)]; ok {
+				b.items[i].State = models.WorkItemState(state)
+			}
+		}
+	}
+
+	req := BoardMoveRequestMsg{Board: *board, Item: item, Column: col, Done: done}
+	return func() tea.Msg { return req }
+}
+
+// View renders the board view
+func (b *BoardView) View() string {
+	title := "Board"
+	if board := b.current(); board != nil {
+		title = "Board: " + board.Name
+	}
+	titleBar := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Width(b.width).
+		Render(title)
+
+	bodyHeight := b.height - 3
+	if bodyHeight < 1 {
+		bodyHeight = 1
+	}
+
+	var message string
+	switch {
+	case b.loading:
+		message = b.styles.Subtitle.Render("Loading board...")
+	case b.current() == nil && b.errMsg != "":
+		message = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(b.errMsg)
+	case b.current() == nil:
+		message = b.styles.Subtitle.Render("The team has no boards")
+	}
+	if message != "" {
+		body := b.styles.PanelActive.Width(b.width - 2).Height(bodyHeight - 2).Render(message)
+		return lipgloss.JoinVertical(lipgloss.Left, titleBar, body, b.renderStatusBar())
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, titleBar, b.renderBoard(bodyHeight+1), b.renderStatusBar())
+}
+
+// renderBoard renders column headers and the cards of the visible columns, one
+// block per swimlane, scrolled to keep the selected card in view
+func (b *BoardView) renderBoard(height int) string {
+	board := b.current()
+	visible := b.visibleColumns()
+	colWidth := (b.width+1)/visible - 1
+	end := b.colOffset + visible
+	if end > len(board.Columns) {
+		end = len(board.Columns)
+	}
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	sep := mutedStyle.Render("│")
+
+	// Column headers with item counts against WIP limits
+	var headers []string
+	columnCards := make(map[int][]models.WorkItem)
+	for c := b.colOffset; c < end; c++ {
+		cards := b.cards(c)
+		columnCards[c] = cards
+		headers = append(headers, b.renderColumnHeader(&board.Columns[c], len(cards), c == b.col, colWidth))
+	}
+	header := strings.Join(headers, sep)
+
+	// Cards, two lines each, in rows aligned across columns per swimlane
+	var lines []string
+	selectedLine := 0
+	for r, row := range board.Rows {
+		lane := make(map[int][]models.WorkItem)
+		laneHeight := 0
+		for c := b.colOffset; c < end; c++ {
+			for _, card := range columnCards[c] {
+				if board.RowOf(&card) == r {
+					lane[c] = append(lane[c], card)
+				}
+			}
+			if len(lane[c]) > laneHeight {
+				laneHeight = len(lane[c])
+			}
+		}
+		if len(board.Rows) > 1 {
+			name := row.Name
+			if name == "" {
+				name = "Default lane"
+			}
+			lines = append(lines, lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#06B6D4")).
+				Render(runewidth.Truncate("── "+name+" "+strings.Repeat("─", b.width), b.width, "")))
+		}
+		if laneHeight == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		for i := 0; i < laneHeight; i++ {
+			var top, bottom []string
+			for c := b.colOffset; c < end; c++ {
+				if i >= len(lane[c]) {
+					top = append(top, strings.Repeat(" ", colWidth))
+					bottom = append(bottom, strings.Repeat(" ", colWidth))
+					continue
+				}
+				card := lane[c][i]
+				selected := c == b.col && b.isSelected(card.ID)
+				if selected {
+					selectedLine = len(lines)
+				}
+				t, bt := b.renderCard(&card, &board.Columns[c], selected, colWidth)
+				top = append(top, t)
+				bottom = append(bottom, bt)
+			}
+			lines = append(lines, strings.Join(top, sep), strings.Join(bottom, sep))
+		}
+	}
+
+	// Header, message line and the scrolled card lines
+	cardHeight := height - 3
+	if cardHeight < 2 {
+		cardHeight = 2
+	}
+	start := 0
+	if selectedLine+2 > cardHeight {
+		start = selectedLine + 2 - cardHeight
+	}
+	stop := start + cardHeight
+	if stop > len(lines) {
+		stop = len(lines)
+	}
+
+	var status string
+	switch {
+	case b.errMsg != "":
+		status = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(b.errMsg)
+	case b.notice != "":
+		status = lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Render(b.notice)
+	case b.colOffset > 0 || end < len(board.Columns):
+		status = mutedStyle.Render(fmt.Sprintf("Columns %d-%d of %d", b.colOffset+1, end, len(board.Columns)))
+	}
+
+	out := []string{header, mutedStyle.Render(strings.Repeat("─", b.width)), status}
+	out = append(out, lines[start:stop]...)
+	for len(out) < height {
+		out = append(out, "")
+	}
+	return strings.Join(out, "\n")
+}
+
+// isSelected reports whether a card is the selected one
+func (b *BoardView) isSelected(id int) bool {
+	item := b.selectedCard()
+	return item != nil && item.ID == id
+}
+
+// renderColumnHeader renders a column's name and count, highlighting WIP limit
+// violations
+func (b *BoardView) renderColumnHeader(col *models.BoardColumn, count int, selected bool, width int) string {
+	countText := fmt.Sprintf("%d", count)
+	style := lipgloss.NewStyle().Bold(true)
+	if col.HasWIPLimit() {
+		countText = fmt.Sprintf("%d/%d", count, col.ItemLimit)
+		switch {
+		case count > col.ItemLimit:
+			countText += " over WIP"
+			style = style.Foreground(lipgloss.Color("#EF4444"))
+		case count == col.ItemLimit:
+			style = style.Foreground(lipgloss.Color("#F59E0B"))
+		}
+	}
+	if selected {
+		style = style.Underline(true)
+	}
+
+	name := col.Name
+	if col.IsSplit {
+		name += " ◧"
+	}
+	name = runewidth.Truncate(name, width-len(countText)-1, "…")
+	return style.Render(fitCell(name+" "+countText, width))
+}
+
+// renderCard renders a card as two lines of the column width
+func (b *BoardView) renderCard(item *models.WorkItem, col *models.BoardColumn, selected bool, width int) (string, string) {
+	prefix := "  "
+	if selected {
+		prefix = "▸ "
+	}
+	top := fitCell(fmt.Sprintf("%s#%d %s", prefix, item.ID, item.Title), width)
+
+	var details []string
+	if col.IsSplit && item.BoardColumnDone {
+		details = append(details, "✓ done")
+	}
+	if item.AssignedTo != "" {
+		details = append(details, item.AssignedTo)
+	}
+	if item.Points() > 0 {
+		details = append(details, models.FormatPoints(item.Points())+" pts")
+	}
+	bottom := fitCell("  "+strings.Join(details, " · "), width)
+
+	_, busy := b.pending[item.ID]
+	switch {
+	case selected:
+		style := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+		return style.Render(top), style.Render(bottom)
+	case busy:
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+		return style.Render(top), style.Render(bottom)
+	}
+	return top, lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(bottom)
+}
+
+// fitCell truncates or pads s to exactly width terminal cells
+func fitCell(s string, width int) string {
+	return runewidth.FillRight(runewidth.Truncate(s, width, "…"), width)
+}
+
+func (b *BoardView) renderStatusBar() string {
+	help := "Esc Back  h/j/k/l Select  </> Move card  d Doing/Done  Enter Open  [/] Board  Ctrl+r Reload"
+	if len(b.pending) > 0 {
+		help += fmt.Sprintf("  Saving %d...", len(b.pending))
+	}
+	return b.styles.StatusBar.
+		Width(b.width).
+		Render(help)
+}
+
+// SetBoards sets the team's boards and shows the one with the most listed items
+func (b *BoardView) SetBoards(boards []models.Board) {
+	b.boards = boards
+	b.loading = false
+	b.board = 0
+	best := -1
+	for i := range boards {
+		count := 0
+		for _, item := range b.items {
+			if boards[i].ColumnOf(&item) >= 0 {
+				count++
+			}
+		}
+		if count > best {
+			b.board, best = i, count
+		}
+	}
+	b.col, b.card, b.colOffset = 0, 0, 0
+}
+
+// HasBoards reports whether the boards are loaded
+func (b *BoardView) HasBoards() bool {
+	return b.boards != nil
+}
+
+// SetItems sets the work items placed on the board
+func (b *BoardView) SetItems(items []models.WorkItem) {
+	b.items = make([]models.WorkItem, len(items))
+	copy(b.items, items)
+	b.pending = make(map[int]models.WorkItem)
+	b.selectCard(b.card)
+}
+
+// SetLoading marks the boards as loading
+func (b *BoardView) SetLoading() {
+	b.loading = true
+	b.notice, b.errMsg = "", ""
+}
+
+// MoveDone replaces a moved item with its updated version
+func (b *BoardView) MoveDone(item models.WorkItem, notice string) {
+	delete(b.pending, item.ID)
+	for i := range b.items {
+		if b.items[i].ID == item.ID {
+			b.items[i] = item
+		}
+	}
+	b.notice = notice
+}
+
+// MoveFailed puts a card back where it was before a failed update
+func (b *BoardView) MoveFailed(id int, err string) {
+	if orig, ok := b.pending[id]; ok {
+		for i := range b.items {
+			if b.items[i].ID == id {
+				b.items[i] = orig
+			}
+		}
+		delete(b.pending, id)
+	}
+	b.notice = ""
+	b.errMsg = err
+	b.selectCard(b.card)
+}
+
+// SetError shows an error, ending the loading state
+func (b *BoardView) SetError(err string) {
+	b.errMsg = err
+	b.loading = false
+}
+
+// SetSize sets the size of the board view
+func (b *BoardView) SetSize(width, height int) {
+	b.width = width
+	b.height = height
+}
+
+// BoardMoveRequestMsg is sent when a card is moved to another column or between
+// Doing and Done
+type BoardMoveRequestMsg struct {
+	Board  models.Board
+	Item   models.WorkItem // The item before the move
+	Column int
+	Done   bool
+}
+
+// CloseBoardMsg is sent when the board view should be closed
+type CloseBoardMsg struct{}
+
+// ReloadBoardMsg is sent when the board and its items should be loaded again
+type ReloadBoardMsg struct{}
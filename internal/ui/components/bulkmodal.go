@@ -0,0 +1,476 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// BulkAction is a change applied to all marked work items
+type BulkAction int
+
+const (
+	BulkChangeState BulkAction = iota
+	BulkAssign
+	BulkMoveIteration
+	BulkMoveArea
+	BulkAddTag
+	BulkRemoveTag
+)
+
+var bulkActions = []BulkAction{BulkChangeState, BulkAssign, BulkMoveIteration, BulkMoveArea, BulkAddTag, BulkRemoveTag}
+
+// String returns the action as shown in the menu
+func (a BulkAction) String() string {
+	switch a {
+	case BulkChangeState:
+		return "Change state"
+	case BulkAssign:
+		return "Assign"
+	case BulkMoveIteration:
+		return "Move to iteration"
+	case BulkMoveArea:
+		return "Move to area"
+	case BulkAddTag:
+		return "Add tag"
+	case BulkRemoveTag:
+		return "Remove tag"
+	}
+	return "Unknown"
+}
+
+// BulkResult is the outcome of a bulk action on one work item
+type BulkResult struct {
+	ID    int
+	Title string
+	Err   string // Empty if the update succeeded
+}
+
+type bulkStep int
+
+const (
+	bulkStepAction bulkStep = iota
+	bulkStepValue
+	bulkStepRunning
+	bulkStepDone
+)
+
+// bulkOption is a value to choose in the second step
+type bulkOption struct {
+	label string
+	value string
+}
+
+// BulkModal is a modal for applying one change to several work items
+type BulkModal struct {
+	visible bool
+	step    bulkStep
+	items   []models.WorkItem
+	action  BulkAction
+	cursor  int
+	input   textinput.Model
+
+	// Values to pick from, per action
+	statesByType map[string][]models.WorkItemStateInfo
+	members      []models.TeamMember
+	iterations   []models.Iteration
+	areas        []models.Area
+	knownTags    []string
+
+	options  []bulkOption
+	filtered []bulkOption
+
+	done    int
+	total   int
+	results []BulkResult
+
+	styles theme.Styles
+	keys   theme.KeyMap
+	width  int
+	height int
+}
+
+// NewBulkModal creates a new bulk action modal
+func NewBulkModal(styles theme.Styles, keys theme.KeyMap) BulkModal {
+	ti := textinput.New()
+	ti.Placeholder = "Type to filter..."
+	ti.CharLimit = 100
+	ti.Width = 40
+
+	return BulkModal{
+		input:  ti,
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the modal
+func (m BulkModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m BulkModal) Update(msg tea.Msg) (BulkModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch m.step {
+	case bulkStepAction:
+		switch {
+		case key.Matches(keyMsg, m.keys.Back):
+			m.visible = false
+			return m, func() tea.Msg { return ModalClosedMsg{} }
+		case key.Matches(keyMsg, m.keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(keyMsg, m.keys.Down):
+			if m.cursor < len(bulkActions)-1 {
+				m.cursor++
+			}
+		case key.Matches(keyMsg, m.keys.Select):
+			m.action = bulkActions[m.cursor]
+			m.step = bulkStepValue
+			m.cursor = 0
+			m.options = m.buildOptions()
+			m.input.SetValue("")
+			m.input.Placeholder = "Type to filter..."
+			if m.action == BulkAddTag {
+				m.input.Placeholder = "Tag name..."
+			}
+			m.applyFilter()
+			m.input.Focus()
+			return m, textinput.Blink
+		}
+	case bulkStepValue:
+		return m.updateValue(keyMsg)
+	case bulkStepRunning:
+		if key.Matches(keyMsg, m.keys.Back) {
+			// Stops the run; the app cancels the remaining batches
+			m.visible = false
+			return m, func() tea.Msg { return ModalClosedMsg{} }
+		}
+	case bulkStepDone:
+		if key.Matches(keyMsg, m.keys.Back) || keyMsg.String() == "enter" {
+			m.visible = false
+			return m, func() tea.Msg { return ModalClosedMsg{} }
+		}
+	}
+
+	return m, nil
+}
+
+// updateValue handles keys while picking the value of the chosen action
+func (m BulkModal) updateValue(msg tea.KeyMsg) (BulkModal, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		if m.input.Value() != "" {
+			m.input.SetValue("")
+			m.applyFilter()
+			return m, nil
+		}
+		m.step = bulkStepAction
+		m.cursor = int(m.action)
+		m.input.Blur()
+		return m, nil
+	case "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case "down":
+		if m.cursor < len(m.filtered)-1 {
+			m.cursor++
+		}
+		return m, nil
+	case "enter":
+		if m.cursor >= len(m.filtered) {
+			return m, nil
+		}
+		selected := m.filtered[m.cursor]
+		m.input.Blur()
+		m.step = bulkStepRunning
+		m.done, m.total = 0, len(m.items)
+		m.results = nil
+		req := BulkActionRequestMsg{
+			Action: m.action,
+			Value:  selected.value,
+			Label:  selected.label,
+			Items:  m.items,
+		}
+		return m, func() tea.Msg { return req }
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.applyFilter()
+	return m, cmd
+}
+
+// buildOptions returns the values that can be chosen for the current action
+func (m *BulkModal) buildOptions() []bulkOption {
+	var options []bulkOption
+	switch m.action {
+	case BulkChangeState:
+		// States valid for any of the marked work item types, in workflow order
+		seen := make(map[string]bool)
+		for _, item := range m.items {
+			for _, s := range m.statesByType[string(item.Type)] {
+				if !seen[s.Name] {
+					seen[s.Name] = true
+					options = append(options, bulkOption{label: s.Name, value: s.Name})
+				}
+			}
+		}
+	case BulkAssign:
+		options = append(options, bulkOption{label: "Unassigned", value: ""})
+		for _, member := range m.members {
+			options = append(options, bulkOption{label: member.DisplayName, value: member.UniqueName})
+		}
+	case BulkMoveIteration:
+		for _, it := range m.iterations {
+			options = append(options, bulkOption{label: it.Path, value: it.Path})
+		}
+	case BulkMoveArea:
+		for _, area := range m.areas {
+			options = append(options, bulkOption{label: area.Path, value: area.Path})
+		}
+	case BulkAddTag:
+		for _, tag := range m.knownTags {
+			options = append(options, bulkOption{label: tag, value: tag})
+		}
+	case BulkRemoveTag:
+		// Only tags that at least one marked item has
+		seen := make(map[string]bool)
+		var tags []string
+		for _, item := range m.items {
+			for _, tag := range item.Tags {
+				if !seen[strings.ToLower(tag)] {
+					seen[strings.ToLower(tag)] = true
+					tags = append(tags, tag)
+				}
+			}
+		}
+		sort.Strings(tags)
+		for _, tag := range tags {
+			options = append(options, bulkOption{label: tag, value: tag})
+		}
+	}
+	return options
+}
+
+func (m *BulkModal) applyFilter() {
+	filter := strings.ToLower(strings.TrimSpace(m.input.Value()))
+	m.filtered = nil
+	exact := false
+	for _, opt := range m.options {
+		if filter == "" || strings.Contains(strings.ToLower(opt.label), filter) {
+			m.filtered = append(m.filtered, opt)
+		}
+		if strings.ToLower(opt.value) == filter {
+			exact = true
+		}
+	}
+	// A new tag can be typed in
+	if m.action == BulkAddTag && filter != "" && !exact {
+		tag := strings.TrimSpace(m.input.Value())
+		m.filtered = append([]bulkOption{{label: tag + " (new)", value: tag}}, m.filtered...)
+	}
+	if m.cursor >= len(m.filtered) {
+		m.cursor = 0
+	}
+}
+
+// View renders the modal
+func (m BulkModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	modalWidth := 70
+
+	var b strings.Builder
+
+	title := "Bulk Actions"
+	if m.step != bulkStepAction {
+		title = m.action.String()
+	}
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(title) + "\n")
+	b.WriteString(lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF")).
+		Render(fmt.Sprintf("%d work item(s) selected", len(m.items))) + "\n\n")
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+
+	switch m.step {
+	case bulkStepAction:
+		for i, action := range bulkActions {
+			if i == m.cursor {
+				b.WriteString(selectedStyle.Render("▸ "+action.String()) + "\n")
+			} else {
+				b.WriteString("  " + action.String() + "\n")
+			}
+		}
+		b.WriteString("\n" + mutedStyle.Render("Enter: choose  Esc: close"))
+
+	case bulkStepValue:
+		b.WriteString(m.input.View() + "\n\n")
+		if len(m.filtered) == 0 {
+			b.WriteString(mutedStyle.Render("No matching values") + "\n")
+		}
+		// Keep the cursor in a window of visible options
+		maxVisible := 10
+		start := 0
+		if m.cursor >= maxVisible {
+			start = m.cursor - maxVisible + 1
+		}
+		end := start + maxVisible
+		if end > len(m.filtered) {
+			end = len(m.filtered)
+		}
+		for i := start; i < end; i++ {
+			label := truncateStr(m.filtered[i].label, modalWidth-8)
+			if i == m.cursor {
+				b.WriteString(selectedStyle.Render("▸ "+label) + "\n")
+			} else {
+				b.WriteString("  " + label + "\n")
+			}
+		}
+		if len(m.filtered) > maxVisible {
+			b.WriteString(mutedStyle.Render(fmt.Sprintf("  %d of %d", m.cursor+1, len(m.filtered))) + "\n")
+		}
+		b.WriteString("\n" + mutedStyle.Render("Enter: apply  Esc: back"))
+
+	case bulkStepRunning:
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).
+			Render(fmt.Sprintf("Updating %d/%d...", m.done, m.total)) + "\n")
+		b.WriteString(renderProgressBar(m.done, m.total, modalWidth-8))
+		b.WriteString("\n\n" + mutedStyle.Render("Esc: cancel"))
+
+	case bulkStepDone:
+		failed := 0
+		for _, r := range m.results {
+			if r.Err != "" {
+				failed++
+			}
+		}
+		summary := fmt.Sprintf("Updated %d of %d work item(s)", len(m.results)-failed, len(m.results))
+		if failed == 0 {
+			b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Render(summary) + "\n")
+		} else {
+			b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render(summary) + "\n\n")
+			errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+			shown := 0
+			for _, r := range m.results {
+				if r.Err == "" {
+					continue
+				}
+				if shown == 10 {
+					b.WriteString(mutedStyle.Render(fmt.Sprintf("...and %d more", failed-shown)) + "\n")
+					break
+				}
+				b.WriteString(fmt.Sprintf("#%d %s\n", r.ID, truncateStr(r.Title, modalWidth-16)))
+				b.WriteString("  " + errStyle.Render(truncateStr(r.Err, modalWidth-8)) + "\n")
+				shown++
+			}
+		}
+		b.WriteString("\n" + mutedStyle.Render("Enter/Esc: close"))
+	}
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Background(lipgloss.Color("#1F2937"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(b.String()))
+}
+
+// renderProgressBar renders a done/total bar of the given width
+func renderProgressBar(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = done * width / total
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED")).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Render(strings.Repeat("░", width-filled))
+}
+
+// SetItems sets the work items the action applies to and starts at the action menu
+func (m *BulkModal) SetItems(items []models.WorkItem) {
+	m.items = items
+	m.step = bulkStepAction
+	m.cursor = 0
+	m.results = nil
+	m.input.Blur()
+}
+
+// SetChoices sets the values offered for each action. knownTags are tags in use,
+// offered when adding a tag.
+func (m *BulkModal) SetChoices(statesByType map[string][]models.WorkItemStateInfo, members []models.TeamMember,
+	iterations []models.Iteration, areas []models.Area, knownTags []string) {
+	m.statesByType = statesByType
+	m.members = members
+	m.iterations = iterations
+	m.areas = areas
+	m.knownTags = knownTags
+}
+
+// SetProgress updates the number of work items processed so far
+func (m *BulkModal) SetProgress(done, total int) {
+	m.done = done
+	m.total = total
+}
+
+// SetResults ends the run with a per-item result summary
+func (m *BulkModal) SetResults(results []BulkResult) {
+	m.step = bulkStepDone
+	m.results = results
+}
+
+// IsRunning returns whether updates are being applied
+func (m *BulkModal) IsRunning() bool {
+	return m.step == bulkStepRunning
+}
+
+// SetVisible sets the visibility
+func (m *BulkModal) SetVisible(visible bool) {
+	m.visible = visible
+	if !visible {
+		m.input.Blur()
+	}
+}
+
+// IsVisible returns whether the modal is visible
+func (m *BulkModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal container size
+func (m *BulkModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// BulkActionRequestMsg is sent when the user applies a bulk action
+type BulkActionRequestMsg struct {
+	Action BulkAction
+	Value  string // State, user unique name, iteration or area path, or tag
+	Label  string // Value as shown to the user
+	Items  []models.WorkItem
+}
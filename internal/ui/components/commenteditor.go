@@ -0,0 +1,165 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// CommentEditor is a modal for writing a new comment or editing an existing one
+type CommentEditor struct {
+	visible    bool
+	workItemID int
+	commentID  int // Zero for a new comment
+	textarea   textarea.Model
+	errMsg     string
+	styles     theme.Styles
+	keys       theme.KeyMap
+	width      int
+	height     int
+}
+
+// NewCommentEditor creates a new comment editor modal
+func NewCommentEditor(styles theme.Styles, keys theme.KeyMap) CommentEditor {
+	ta := textarea.New()
+	ta.Placeholder = "Write a comment (markdown supported)..."
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetWidth(64)
+	ta.SetHeight(10)
+
+	return CommentEditor{
+		textarea: ta,
+		styles:   styles,
+		keys:     keys,
+	}
+}
+
+// Init initializes the modal
+func (m CommentEditor) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m CommentEditor) Update(msg tea.Msg) (CommentEditor, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.visible = false
+			m.textarea.Blur()
+			return m, func() tea.Msg { return ModalClosedMsg{} }
+		case "ctrl+s":
+			text := strings.TrimSpace(m.textarea.Value())
+			if text == "" {
+				m.errMsg = "Comment cannot be empty"
+				return m, nil
+			}
+			req := CommentSubmitMsg{
+				WorkItemID: m.workItemID,
+				CommentID:  m.commentID,
+				Text:       text,
+			}
+			return m, func() tea.Msg { return req }
+		case "ctrl+e":
+			text := m.textarea.Value()
+			return m, func() tea.Msg { return ComposeCommentInEditorMsg{Text: text} }
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return m, cmd
+}
+
+// View renders the modal
+func (m CommentEditor) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	var b strings.Builder
+
+	heading := "New comment on #" + itoa(m.workItemID)
+	if m.commentID != 0 {
+		heading = "Edit comment on #" + itoa(m.workItemID)
+	}
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(heading) + "\n\n")
+	b.WriteString(m.textarea.View() + "\n")
+
+	if m.errMsg != "" {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+		b.WriteString("\n" + errStyle.Render(m.errMsg) + "\n")
+	}
+
+	b.WriteString("\n")
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	b.WriteString(helpStyle.Render("Ctrl+s: save  Ctrl+e: open in $EDITOR  Esc: cancel"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(70).
+		Background(lipgloss.Color("#1F2937"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(b.String()))
+}
+
+// Open shows the editor for a work item. commentID is zero for a new comment;
+// text pre-fills the editor when editing.
+func (m *CommentEditor) Open(workItemID, commentID int, text string) tea.Cmd {
+	m.workItemID = workItemID
+	m.commentID = commentID
+	m.errMsg = ""
+	m.textarea.SetValue(text)
+	m.visible = true
+	return m.textarea.Focus()
+}
+
+// SetText replaces the text, e.g. after editing it in $EDITOR
+func (m *CommentEditor) SetText(text string) {
+	m.textarea.SetValue(strings.TrimRight(text, "\n"))
+}
+
+// SetError shows an error in the editor
+func (m *CommentEditor) SetError(err string) {
+	m.errMsg = err
+}
+
+// SetVisible sets the visibility
+func (m *CommentEditor) SetVisible(visible bool) {
+	m.visible = visible
+	if !visible {
+		m.textarea.Blur()
+	}
+}
+
+// IsVisible returns whether the modal is visible
+func (m *CommentEditor) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal container size
+func (m *CommentEditor) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// ComposeCommentInEditorMsg is sent when the comment should be continued in $EDITOR
+type ComposeCommentInEditorMsg struct {
+	Text string
+}
+
+// CommentSubmitMsg is sent when the user saves a comment
+type CommentSubmitMsg struct {
+	WorkItemID int
+	CommentID  int // Zero for a new comment
+	Text       string
+}
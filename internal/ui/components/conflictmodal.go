@@ -0,0 +1,205 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// FieldChange is a pending change to a single work item field
+type FieldChange struct {
+	Field string      // Reference name, e.g. "System.State"
+	Label string      // Display label, e.g. "State"
+	Base  string      // Display value when the item was loaded
+	Ours  string      // Display value we want to set
+	Value interface{} // Value sent to the server
+}
+
+// ConflictResolution is the user's choice in the conflict modal
+type ConflictResolution int
+
+const (
+	ConflictRetry   ConflictResolution = iota // Re-apply all our changes on top of the server's revision
+	ConflictMerge                             // Apply only changes to fields the server did not touch
+	ConflictDiscard                           // Drop our changes and keep the server's values
+)
+
+// ConflictModal shows our change vs. the server's current values after a revision conflict
+type ConflictModal struct {
+	visible bool
+	server  *models.WorkItem
+	changes []FieldChange
+	styles  theme.Styles
+	keys    theme.KeyMap
+	width   int
+	height  int
+}
+
+// NewConflictModal creates a new conflict modal
+func NewConflictModal(styles theme.Styles, keys theme.KeyMap) ConflictModal {
+	return ConflictModal{
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the modal
+func (m ConflictModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m ConflictModal) Update(msg tea.Msg) (ConflictModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.ConflictRetry):
+			return m, m.resolve(ConflictRetry)
+		case key.Matches(msg, m.keys.ConflictMerge):
+			return m, m.resolve(ConflictMerge)
+		case key.Matches(msg, m.keys.ConflictDiscard, m.keys.Back):
+			return m, m.resolve(ConflictDiscard)
+		}
+	}
+
+	return m, nil
+}
+
+// resolve emits the resolution with the changes that should still be sent
+func (m ConflictModal) resolve(resolution ConflictResolution) tea.Cmd {
+	if m.server == nil {
+		return nil
+	}
+
+	var changes []FieldChange
+	switch resolution {
+	case ConflictRetry:
+		changes = m.changes
+	case ConflictMerge:
+		for _, c := range m.changes {
+			if !m.isConflicted(c) {
+				changes = append(changes, c)
+			}
+		}
+	}
+
+	server := *m.server
+	return func() tea.Msg {
+		return ConflictResolvedMsg{
+			Resolution: resolution,
+			Server:     server,
+			Changes:    changes,
+		}
+	}
+}
+
+// isConflicted returns true if the server changed the field since we loaded it
+func (m ConflictModal) isConflicted(c FieldChange) bool {
+	return m.server.FieldValue(c.Field) != c.Base
+}
+
+// View renders the modal
+func (m ConflictModal) View() string {
+	if !m.visible || m.server == nil {
+		return ""
+	}
+
+	modalWidth := 70
+	labelW, valueW := 14, 24
+
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F59E0B")).Render("Conflict")
+	itemInfo := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF")).
+		Render("#" + itoa(m.server.ID) + " " + truncateStr(m.server.Title, 50))
+	b.WriteString(title + "\n")
+	b.WriteString(itemInfo + "\n\n")
+
+	changedBy := m.server.ChangedBy
+	if changedBy == "" {
+		changedBy = "someone else"
+	}
+	b.WriteString("Changed by " + changedBy + " since you loaded it.\n\n")
+
+	// Header
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#9CA3AF"))
+	b.WriteString(headerStyle.Render(padRight("FIELD", labelW)+padRight("YOURS", valueW)+"SERVER") + "\n")
+
+	conflictStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB"))
+	for _, c := range m.changes {
+		theirs := m.server.FieldValue(c.Field)
+		row := padRight(truncateStr(c.Label, labelW-1), labelW) +
+			padRight(truncateStr(displayValue(c.Ours), valueW-1), valueW) +
+			truncateStr(displayValue(theirs), valueW)
+		if m.isConflicted(c) {
+			b.WriteString(conflictStyle.Render(row+" !") + "\n")
+		} else {
+			b.WriteString(okStyle.Render(row) + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	b.WriteString(hintStyle.Render("! = also changed on the server") + "\n\n")
+
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	retry, merge, discard := m.keys.ConflictRetry.Help(), m.keys.ConflictMerge.Help(), m.keys.ConflictDiscard.Help()
+	b.WriteString(helpStyle.Render(retry.Key + ": " + retry.Desc + "  " + merge.Key + ": " + merge.Desc + "  " +
+		discard.Key + "/" + m.keys.Back.Help().Key + ": " + discard.Desc))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#F59E0B")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Background(lipgloss.Color("#1F2937"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(b.String()))
+}
+
+// displayValue renders empty values visibly
+func displayValue(s string) string {
+	if s == "" {
+		return "(empty)"
+	}
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// SetConflict sets the server's current item and the changes that conflicted
+func (m *ConflictModal) SetConflict(server *models.WorkItem, changes []FieldChange) {
+	m.server = server
+	m.changes = changes
+}
+
+// SetVisible sets the visibility
+func (m *ConflictModal) SetVisible(visible bool) {
+	m.visible = visible
+}
+
+// IsVisible returns whether the modal is visible
+func (m *ConflictModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal container size
+func (m *ConflictModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// ConflictResolvedMsg is sent when the user picks how to resolve a conflict
+type ConflictResolvedMsg struct {
+	Resolution ConflictResolution
+	Server     models.WorkItem // The server's current item (with its current Rev)
+	Changes    []FieldChange   // Changes to send; empty for discard
+}
@@ -0,0 +1,317 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// Rows of the create form
+const (
+	createRowType = iota
+	createRowTitle
+	createRowIteration
+	createRowArea
+	createRowParent
+	createRowCount
+)
+
+// CreateModal is a modal for creating a new work item
+type CreateModal struct {
+	visible        bool
+	types          []string
+	typeIndex      int
+	row            int
+	titleInput     textinput.Model
+	iterationInput textinput.Model
+	areaInput      textinput.Model
+	parent         *models.WorkItem
+	useParent      bool
+	errMsg         string
+	styles         theme.Styles
+	keys           theme.KeyMap
+	width          int
+	height         int
+}
+
+// NewCreateModal creates a new create modal
+func NewCreateModal(styles theme.Styles, keys theme.KeyMap) CreateModal {
+	newInput := func(placeholder string) textinput.Model {
+		ti := textinput.New()
+		ti.Placeholder = placeholder
+		ti.CharLimit = 255
+		ti.Width = 44
+		return ti
+	}
+
+	return CreateModal{
+		titleInput:     newInput("Title"),
+		iterationInput: newInput("Project default"),
+		areaInput:      newInput("Project default"),
+		styles:         styles,
+		keys:           keys,
+	}
+}
+
+// Init initializes the modal
+func (m CreateModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m CreateModal) Update(msg tea.Msg) (CreateModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyEsc:
+		m.visible = false
+		return m, func() tea.Msg { return ModalClosedMsg{} }
+	case tea.KeyUp, tea.KeyShiftTab:
+		m.focusRow(m.prevRow())
+		return m, nil
+	case tea.KeyDown, tea.KeyTab:
+		m.focusRow(m.nextRow())
+		return m, nil
+	case tea.KeyEnter:
+		return m, m.submit()
+	}
+
+	switch m.row {
+	case createRowType:
+		switch keyMsg.String() {
+		case "left", "h":
+			if len(m.types) > 0 {
+				m.typeIndex = (m.typeIndex - 1 + len(m.types)) % len(m.types)
+			}
+		case "right", "l", " ":
+			if len(m.types) > 0 {
+				m.typeIndex = (m.typeIndex + 1) % len(m.types)
+			}
+		}
+		return m, nil
+	case createRowParent:
+		switch keyMsg.String() {
+		case "left", "right", "h", "l", " ":
+			m.useParent = !m.useParent
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	input := m.activeInput()
+	*input, cmd = input.Update(keyMsg)
+	return m, cmd
+}
+
+// submit validates the form and emits a create request
+func (m *CreateModal) submit() tea.Cmd {
+	if len(m.types) == 0 {
+		return nil
+	}
+
+	title := strings.TrimSpace(m.titleInput.Value())
+	if title == "" {
+		m.errMsg = "Title is required"
+		m.focusRow(createRowTitle)
+		return nil
+	}
+
+	req := CreateWorkItemRequestMsg{
+		Type:          m.types[m.typeIndex],
+		Title:         title,
+		IterationPath: strings.TrimSpace(m.iterationInput.Value()),
+		AreaPath:      strings.TrimSpace(m.areaInput.Value()),
+	}
+	if m.useParent && m.parent != nil {
+		req.Parent = m.parent
+	}
+	return func() tea.Msg { return req }
+}
+
+// activeInput returns the text input of the focused row
+func (m *CreateModal) activeInput() *textinput.Model {
+	switch m.row {
+	case createRowIteration:
+		return &m.iterationInput
+	case createRowArea:
+		return &m.areaInput
+	}
+	return &m.titleInput
+}
+
+func (m *CreateModal) nextRow() int {
+	next := (m.row + 1) % createRowCount
+	if next == createRowParent && m.parent == nil {
+		next = createRowType
+	}
+	return next
+}
+
+func (m *CreateModal) prevRow() int {
+	prev := (m.row - 1 + createRowCount) % createRowCount
+	if prev == createRowParent && m.parent == nil {
+		prev--
+	}
+	return prev
+}
+
+// focusRow moves focus to the given row, focusing its text input if it has one
+func (m *CreateModal) focusRow(row int) {
+	m.row = row
+	m.titleInput.Blur()
+	m.iterationInput.Blur()
+	m.areaInput.Blur()
+	switch row {
+	case createRowTitle, createRowIteration, createRowArea:
+		m.activeInput().Focus()
+	}
+}
+
+// View renders the modal
+func (m CreateModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	modalWidth := 64
+	labelW := 12
+
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Render("New Work Item")
+	b.WriteString(title + "\n\n")
+
+	labelStyle := func(row int) lipgloss.Style {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+		if row == m.row {
+			style = style.Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+		}
+		return style
+	}
+	cursor := func(row int) string {
+		if row == m.row {
+			return "▸ "
+		}
+		return "  "
+	}
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	// Type
+	typeValue := mutedStyle.Render("Loading types...")
+	if len(m.types) > 0 {
+		name := m.types[m.typeIndex]
+		typeValue = "‹ " + m.styles.TypeBadge(name).Render(name) + " ›"
+	}
+	b.WriteString(cursor(createRowType) + labelStyle(createRowType).Render(padRight("Type", labelW)) + typeValue + "\n")
+
+	// Text fields
+	b.WriteString(cursor(createRowTitle) + labelStyle(createRowTitle).Render(padRight("Title", labelW)) + m.titleInput.View() + "\n")
+	b.WriteString(cursor(createRowIteration) + labelStyle(createRowIteration).Render(padRight("Iteration", labelW)) + m.iterationInput.View() + "\n")
+	b.WriteString(cursor(createRowArea) + labelStyle(createRowArea).Render(padRight("Area", labelW)) + m.areaInput.View() + "\n")
+
+	// Parent
+	if m.parent != nil {
+		check := "[ ]"
+		if m.useParent {
+			check = "[x]"
+		}
+		parent := check + " #" + itoa(m.parent.ID) + " " + truncateStr(m.parent.Title, modalWidth-labelW-18)
+		b.WriteString(cursor(createRowParent) + labelStyle(createRowParent).Render(padRight("Parent", labelW)) + parent + "\n")
+	}
+
+	if m.errMsg != "" {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+		b.WriteString("\n" + errStyle.Render(m.errMsg) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("↑/↓: field  ←/→: change  Enter: create  Esc: cancel"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Background(lipgloss.Color("#1F2937"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(b.String()))
+}
+
+// Reset clears the form and pre-fills iteration and area. parent is the item offered
+// as the new item's parent; it may be nil.
+func (m *CreateModal) Reset(iterationPath, areaPath string, parent *models.WorkItem) {
+	m.titleInput.SetValue("")
+	m.iterationInput.SetValue(iterationPath)
+	m.areaInput.SetValue(areaPath)
+	m.parent = parent
+	m.useParent = false
+	m.errMsg = ""
+	m.focusRow(createRowTitle)
+	m.selectDefaultType()
+}
+
+// SetTypes sets the work item types that can be created
+func (m *CreateModal) SetTypes(types []string) {
+	m.types = types
+	m.selectDefaultType()
+}
+
+// selectDefaultType preselects Task, the most commonly created type
+func (m *CreateModal) selectDefaultType() {
+	m.typeIndex = 0
+	for i, t := range m.types {
+		if t == "Task" {
+			m.typeIndex = i
+			return
+		}
+	}
+}
+
+// HasTypes returns whether work item types have been loaded
+func (m *CreateModal) HasTypes() bool {
+	return len(m.types) > 0
+}
+
+// SetError shows an error in the form
+func (m *CreateModal) SetError(err string) {
+	m.errMsg = err
+}
+
+// SetVisible sets the visibility
+func (m *CreateModal) SetVisible(visible bool) {
+	m.visible = visible
+	if !visible {
+		m.focusRow(createRowType)
+	}
+}
+
+// IsVisible returns whether the modal is visible
+func (m *CreateModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal container size
+func (m *CreateModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// CreateWorkItemRequestMsg is sent when the user submits the create form
+type CreateWorkItemRequestMsg struct {
+	Type          string
+	Title         string
+	IterationPath string // Empty uses the project default
+	AreaPath      string // Empty uses the project default
+	Parent        *models.WorkItem
+}
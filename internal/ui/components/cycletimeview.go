@@ -0,0 +1,380 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// cycleTimeRanges are the date ranges, in days, the cycle time view cycles through
+var cycleTimeRanges = []int{30, 60, 90, 180}
+
+// cycleTimeColors are the colors of the work item types in the scatter plot
+var cycleTimeColors = []string{"#06B6D4", "#EF4444", "#F59E0B", "#10B981", "#60A5FA", "#F472B6"}
+
+// CycleTimeView is the fullscreen report of how long the recently completed items
+// took from start (cycle time) or creation (lead time) to completion
+type CycleTimeView struct {
+	timings    []models.ItemTiming // Ordered by completion
+	stats      []models.TimingStats
+	rangeIndex int  // Index into cycleTimeRanges
+	lead       bool // Plot lead time rather than cycle time
+	list       bool // Show the items rather than the scatter plot
+	cursor     int  // Selected item in the list, newest first
+	offset     int
+	loading    bool
+	errMsg     string
+	styles     theme.Styles
+	keys       theme.KeyMap
+	width      int
+	height     int
+}
+
+// NewCycleTimeView creates a new cycle time view
+func NewCycleTimeView(styles theme.Styles, keys theme.KeyMap) CycleTimeView {
+	return CycleTimeView{
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the cycle time view
+func (c CycleTimeView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the cycle time view
+func (c *CycleTimeView) Update(msg tea.Msg) (*CycleTimeView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return c, nil
+	}
+
+	reload := func() tea.Msg { return ReloadCycleTimeMsg{} }
+	switch {
+	case key.Matches(keyMsg, c.keys.Back):
+		return c, func() tea.Msg { return CloseCycleTimeMsg{} }
+	case key.Matches(keyMsg, c.keys.Quit) && keyMsg.String() == "q":
+		return c, func() tea.Msg { return CloseCycleTimeMsg{} }
+	case key.Matches(keyMsg, c.keys.Refresh):
+		return c, reload
+	case (keyMsg.String() == "+" || keyMsg.String() == "=") && c.rangeIndex < len(cycleTimeRanges)-1:
+		c.rangeIndex++
+		return c, reload
+	case keyMsg.String() == "-" && c.rangeIndex > 0:
+		c.rangeIndex--
+		return c, reload
+	case keyMsg.String() == "c":
+		c.lead = !c.lead
+	case key.Matches(keyMsg, c.keys.NextPanel):
+		c.list = !c.list
+	case c.list && key.Matches(keyMsg, c.keys.Down):
+		if c.cursor < len(c.timings)-1 {
+			c.cursor++
+		}
+	case c.list && key.Matches(keyMsg, c.keys.Up):
+		if c.cursor > 0 {
+			c.cursor--
+		}
+	case c.list && key.Matches(keyMsg, c.keys.Top):
+		c.cursor = 0
+	case c.list && key.Matches(keyMsg, c.keys.Bottom):
+		c.cursor = max(len(c.timings)-1, 0)
+	}
+
+	return c, nil
+}
+
+// Since returns the first day to report on
+func (c *CycleTimeView) Since() time.Time {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return today.AddDate(0, 0, -cycleTimeRanges[c.rangeIndex]+1)
+}
+
+// metric returns the plotted time of an item and whether it has one
+func (c *CycleTimeView) metric(t models.ItemTiming) (time.Duration, bool) {
+	if c.lead {
+		return t.LeadTime(), true
+	}
+	return t.CycleTime(), t.HasCycleTime()
+}
+
+// metricName returns the name of the plotted time
+func (c *CycleTimeView) metricName() string {
+	if c.lead {
+		return "Lead time"
+	}
+	return "Cycle time"
+}
+
+// View renders the cycle time view
+func (c *CycleTimeView) View() string {
+	titleBar := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Width(c.width).
+		Render("Cycle & Lead Time")
+
+	bodyHeight := c.height - 2
+	if bodyHeight < 3 {
+		bodyHeight = 3
+	}
+
+	var message string
+	switch {
+	case c.loading:
+		message = c.styles.Subtitle.Render("Loading state history...")
+	case c.errMsg != "":
+		message = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(c.errMsg)
+	case len(c.timings) == 0:
+		message = c.styles.Subtitle.Render("No items completed in this range")
+	}
+	if message != "" {
+		body := c.styles.PanelActive.Width(c.width - 2).Height(bodyHeight - 2).Render(c.renderScope() + "\n\n" + message)
+		return lipgloss.JoinVertical(lipgloss.Left, titleBar, body, c.renderStatusBar())
+	}
+
+	stats := c.renderStats()
+	rest := bodyHeight - lipgloss.Height(stats) - 3
+	var main string
+	if c.list {
+		main = c.renderList(rest)
+	} else {
+		main = c.renderScatter(rest)
+	}
+
+	body := lipgloss.JoinVertical(lipgloss.Left, c.renderScope(), "", stats, "", main)
+	body = lipgloss.NewStyle().Width(c.width).Height(bodyHeight).MaxHeight(bodyHeight).Render(body)
+	return lipgloss.JoinVertical(lipgloss.Left, titleBar, body, c.renderStatusBar())
+}
+
+// renderScope renders the range and number of items reported on
+func (c *CycleTimeView) renderScope() string {
+	nameStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#06B6D4"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	return " " + nameStyle.Render(fmt.Sprintf("Last %d days", cycleTimeRanges[c.rangeIndex])) +
+		mutedStyle.Render(fmt.Sprintf(" · since %s · %d items completed", c.Since().Format("2 Jan"), len(c.timings)))
+}
+
+// renderStats renders the percentiles per work item type
+func (c *CycleTimeView) renderStats() string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#9CA3AF"))
+	totalStyle := lipgloss.NewStyle().Bold(true)
+
+	lines := []string{" " + headerStyle.Render(padRight("Type", 22)+padRight("Items", 7)+
+		padRight("Cycle 50%", 11)+padRight("85%", 8)+padRight("95%", 9)+
+		padRight("Lead 50%", 10)+padRight("85%", 8)+"95%")}
+	for _, s := range c.stats {
+		name := c.typeStyle(s.Type).Render("● ") + padRight(truncateStr(string(s.Type), 19), 20)
+		if s.Type == "" {
+			name = totalStyle.Render(padRight("All types", 22))
+		}
+		cycle := padRight("-", 11) + padRight("-", 8) + padRight("-", 9)
+		if s.Started > 0 {
+			cycle = padRight(models.FormatDays(s.Cycle.P50), 11) + padRight(models.FormatDays(s.Cycle.P85), 8) +
+				padRight(models.FormatDays(s.Cycle.P95), 9)
+		}
+		lines = append(lines, " "+name+padRight(fmt.Sprintf("%d", s.Items), 7)+cycle+
+			padRight(models.FormatDays(s.Lead.P50), 10)+padRight(models.FormatDays(s.Lead.P85), 8)+
+			models.FormatDays(s.Lead.P95))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// typeStyle returns the style of a work item type's points, by its place in the stats
+func (c *CycleTimeView) typeStyle(typ models.WorkItemType) lipgloss.Style {
+	for i, s := range c.stats {
+		if s.Type == typ {
+			return lipgloss.NewStyle().Foreground(lipgloss.Color(cycleTimeColors[i%len(cycleTimeColors)]))
+		}
+	}
+	return lipgloss.NewStyle()
+}
+
+// renderScatter plots the time of each item against its completion date, with the
+// 50th and 85th percentiles of all items marked
+func (c *CycleTimeView) renderScatter(height int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	p85Style := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+
+	var durations []time.Duration
+	var maxDuration time.Duration
+	for _, t := range c.timings {
+		if d, ok := c.metric(t); ok {
+			durations = append(durations, d)
+			maxDuration = max(maxDuration, d)
+		}
+	}
+	title := " " + lipgloss.NewStyle().Bold(true).Render(c.metricName()) + mutedStyle.Render(" by completion date")
+	if len(durations) == 0 || maxDuration <= 0 {
+		return title + "\n\n" + mutedStyle.Render(" No items were in progress before they were completed")
+	}
+	percentiles := models.PercentilesOf(durations)
+
+	rows := height - 4
+	if rows < 3 {
+		rows = 3
+	}
+	cols := c.width - 8 - 12
+	if cols < 10 {
+		cols = 10
+	}
+	rowOf := func(d time.Duration) int {
+		r := rows - 1 - int(float64(d)/float64(maxDuration)*float64(rows-1)+0.5)
+		return min(max(r, 0), rows-1)
+	}
+	start := c.Since()
+	span := time.Since(start)
+	colOf := func(at time.Time) int {
+		col := int(float64(at.Sub(start)) / float64(span) * float64(cols-1))
+		return min(max(col, 0), cols-1)
+	}
+
+	// Lay out the percentile lines first so the points are drawn over them
+	grid := make([][]string, rows)
+	for r := range grid {
+		grid[r] = make([]string, cols)
+		for col := range grid[r] {
+			grid[r][col] = " "
+		}
+	}
+	p50Row, p85Row := rowOf(percentiles.P50), rowOf(percentiles.P85)
+	for col := 0; col < cols; col++ {
+		grid[p50Row][col] = mutedStyle.Render("┄")
+		grid[p85Row][col] = p85Style.Render("┄")
+	}
+	for _, t := range c.timings {
+		if d, ok := c.metric(t); ok {
+			grid[rowOf(d)][colOf(t.Completed)] = c.typeStyle(t.Item.Type).Render("●")
+		}
+	}
+
+	lines := []string{title, ""}
+	for r := range grid {
+		axis := "      "
+		switch r {
+		case 0:
+			axis = fmt.Sprintf("%6s", models.FormatDays(maxDuration))
+		case rows - 1:
+			axis = fmt.Sprintf("%6s", "0d")
+		}
+		label := ""
+		switch r {
+		case p85Row:
+			label = p85Style.Render(" 85% " + models.FormatDays(percentiles.P85))
+		case p50Row:
+			label = mutedStyle.Render(" 50% " + models.FormatDays(percentiles.P50))
+		}
+		lines = append(lines, mutedStyle.Render(axis+"│")+strings.Join(grid[r], "")+label)
+	}
+	lines = append(lines, mutedStyle.Render("      └"+strings.Repeat("─", cols)))
+
+	first := start.Format("2 Jan")
+	last := time.Now().Format("2 Jan")
+	gap := cols - len(first) - len(last)
+	if gap < 1 {
+		gap = 1
+	}
+	lines = append(lines, mutedStyle.Render("       "+first+strings.Repeat(" ", gap)+last))
+	return strings.Join(lines, "\n")
+}
+
+// renderList renders the items, most recently completed first
+func (c *CycleTimeView) renderList(height int) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#9CA3AF"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	rows := height - 1
+	if rows < 1 {
+		rows = 1
+	}
+	if c.cursor < c.offset {
+		c.offset = c.cursor
+	}
+	if c.cursor >= c.offset+rows {
+		c.offset = c.cursor - rows + 1
+	}
+
+	titleWidth := c.width - 2 - 8 - 20 - 12 - 8 - 8
+	if titleWidth < 10 {
+		titleWidth = 10
+	}
+
+	lines := []string{" " + headerStyle.Render(padRight("ID", 8)+padRight("Type", 20)+padRight("Completed", 12)+
+		padRight("Cycle", 8)+padRight("Lead", 8)+"Title")}
+	for i := c.offset; i < len(c.timings) && i < c.offset+rows; i++ {
+		t := c.timings[len(c.timings)-1-i]
+		cycle := "-"
+		if t.HasCycleTime() {
+			cycle = models.FormatDays(t.CycleTime())
+		}
+		line := padRight(fmt.Sprintf("#%d", t.Item.ID), 8) +
+			padRight(truncateStr(string(t.Item.Type), 19), 20) +
+			padRight(t.Completed.Local().Format("2 Jan 2006"), 12) +
+			padRight(cycle, 8) + padRight(models.FormatDays(t.LeadTime()), 8) +
+			truncateStr(t.Item.Title, titleWidth)
+		if i == c.cursor {
+			lines = append(lines, c.styles.ListItemSelected.Render(" "+line))
+		} else if !t.HasCycleTime() {
+			lines = append(lines, " "+mutedStyle.Render(line))
+		} else {
+			lines = append(lines, " "+line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c *CycleTimeView) renderStatusBar() string {
+	help := "Esc Back  Tab Items  c Cycle/Lead  +/- Days  Ctrl+r Reload"
+	if c.list {
+		help = "Esc Back  Tab Chart  j/k Move  +/- Days  Ctrl+r Reload"
+	}
+	return c.styles.StatusBar.
+		Width(c.width).
+		Render(help)
+}
+
+// SetLoading marks the view as loading
+func (c *CycleTimeView) SetLoading() {
+	c.loading = true
+	c.errMsg = ""
+}
+
+// SetTimings sets the timings of the completed items, ordered by completion
+func (c *CycleTimeView) SetTimings(timings []models.ItemTiming) {
+	c.timings = timings
+	c.stats = models.TimingStatsByType(timings)
+	c.cursor = 0
+	c.offset = 0
+	c.loading = false
+	c.errMsg = ""
+}
+
+// SetError shows an error, ending the loading state
+func (c *CycleTimeView) SetError(err string) {
+	c.errMsg = err
+	c.loading = false
+}
+
+// SetSize sets the size of the cycle time view
+func (c *CycleTimeView) SetSize(width, height int) {
+	c.width = width
+	c.height = height
+}
+
+// CloseCycleTimeMsg is sent when the cycle time view should be closed
+type CloseCycleTimeMsg struct{}
+
+// ReloadCycleTimeMsg is sent when the timings should be loaded again, e.g. for
+// another range
+type ReloadCycleTimeMsg struct{}
@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/samuelenocsson/devops-tui/internal/models"
 	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
 )
@@ -14,6 +15,7 @@ import (
 // DetailsPanel shows details for a selected work item
 type DetailsPanel struct {
 	item              *models.WorkItem
+	change            *models.ItemChange // Found by a background refresh, nil if none
 	styles            theme.Styles
 	keys              theme.KeyMap
 	width             int
@@ -159,6 +161,11 @@ func (d *DetailsPanel) buildContent() string {
 	b.WriteString(d.styles.DetailTitle.Render(title))
 	b.WriteString("\n\n")
 
+	if d.change != nil {
+		b.WriteString(d.renderChange(contentWidth))
+		b.WriteString("\n\n")
+	}
+
 	// Metadata section - use single column layout for better readability
 	typeStyle := d.styles.TypeBadge(string(d.item.Type))
 	stateStyle := d.styles.StateBadge(string(d.item.State))
@@ -446,6 +453,31 @@ func (d *DetailsPanel) buildContent() string {
 	return b.String()
 }
 
+// renderChange renders what a background refresh found changed about the item
+func (d *DetailsPanel) renderChange(width int) string {
+	color := "#F59E0B"
+	switch d.change.Kind {
+	case models.ItemAdded:
+		color = "#10B981"
+	case models.ItemRemoved:
+		color = "#EF4444"
+	}
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(color))
+	fieldStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+
+	lines := []string{headerStyle.Render(truncate("● "+d.change.Summary(), width))}
+	for _, field := range d.change.Fields {
+		lines = append(lines, fieldStyle.Render(truncate("  "+field, width)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SetChange sets what a background refresh found changed about the item, nil if
+// nothing
+func (d *DetailsPanel) SetChange(change *models.ItemChange) {
+	d.change = change
+}
+
 // SetItem sets the work item to display
 func (d *DetailsPanel) SetItem(item *models.WorkItem) {
 	// Reset scroll when item changes
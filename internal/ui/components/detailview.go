@@ -23,13 +23,26 @@ type DetailView struct {
 	maxScroll    int
 	contentLines []string
 	contentBuilt bool
+
+	// Comment management
+	currentUserID   string
+	selectedComment int   // Index into item.Comments, -1 if none
+	commentOffsets  []int // Content line of each comment header
+	confirmDelete   bool
+	notice          string // One-off message shown in the status bar
+
+	// Development links
+	selectedDev int // Index into item.DevelopmentLinks, -1 if none
+	devOffset   int // Content line of the development section
 }
 
 // NewDetailView creates a new detail view
 func NewDetailView(styles theme.Styles, keys theme.KeyMap) DetailView {
 	return DetailView{
-		styles: styles,
-		keys:   keys,
+		styles:          styles,
+		keys:            keys,
+		selectedComment: -1,
+		selectedDev:     -1,
 	}
 }
 
@@ -42,7 +55,78 @@ func (d DetailView) Init() tea.Cmd {
 func (d *DetailView) Update(msg tea.Msg) (*DetailView, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		d.notice = ""
+
+		// Waiting for delete confirmation - anything but "y" cancels
+		if d.confirmDelete {
+			d.confirmDelete = false
+			if msg.String() == "y" {
+				if comment := d.SelectedComment(); comment != nil {
+					req := DeleteCommentRequestMsg{WorkItemID: d.item.ID, CommentID: comment.ID}
+					return d, func() tea.Msg { return req }
+				}
+			}
+			return d, nil
+		}
+
 		switch {
+		case key.Matches(msg, d.keys.AddComment):
+			if d.item != nil {
+				req := ComposeCommentMsg{WorkItemID: d.item.ID}
+				return d, func() tea.Msg { return req }
+			}
+		case key.Matches(msg, d.keys.ManageLinks):
+			if d.item != nil {
+				item := *d.item
+				return d, func() tea.Msg { return ManageLinksMsg{Item: item} }
+			}
+		case key.Matches(msg, d.keys.NextDevLink):
+			d.selectDevLink()
+		case key.Matches(msg, d.keys.OpenDevLink):
+			if d.item == nil || d.selectedDev < 0 || d.selectedDev >= len(d.item.DevelopmentLinks) {
+				d.notice = "Select a pull request, commit or branch with p first"
+				return d, nil
+			}
+			link := d.item.DevelopmentLinks[d.selectedDev]
+			if link.WebURL == "" {
+				d.notice = "No web URL known for " + link.ShortRef()
+				return d, nil
+			}
+			return d, func() tea.Msg { return OpenURLMsg{URL: link.WebURL} }
+		case key.Matches(msg, d.keys.ManageAttachments):
+			if d.item != nil {
+				item := *d.item
+				return d, func() tea.Msg { return ManageAttachmentsMsg{Item: item} }
+			}
+		case key.Matches(msg, d.keys.History):
+			if d.item != nil {
+				item := *d.item
+				return d, func() tea.Msg { return ShowHistoryMsg{Item: item} }
+			}
+		case key.Matches(msg, d.keys.NextComment):
+			d.selectComment(d.selectedComment + 1)
+		case key.Matches(msg, d.keys.PrevComment):
+			d.selectComment(d.selectedComment - 1)
+		case key.Matches(msg, d.keys.EditComment):
+			if comment := d.SelectedComment(); comment != nil {
+				if !d.isOwnComment(*comment) {
+					d.notice = "You can only edit your own comments"
+					return d, nil
+				}
+				req := ComposeCommentMsg{WorkItemID: d.item.ID, CommentID: comment.ID, Text: comment.Text}
+				return d, func() tea.Msg { return req }
+			}
+			d.notice = "Select a comment with [ and ] first"
+		case key.Matches(msg, d.keys.DeleteComment):
+			if comment := d.SelectedComment(); comment != nil {
+				if !d.isOwnComment(*comment) {
+					d.notice = "You can only delete your own comments"
+					return d, nil
+				}
+				d.confirmDelete = true
+				return d, nil
+			}
+			d.notice = "Select a comment with [ and ] first"
 		case key.Matches(msg, d.keys.Back):
 			return d, func() tea.Msg { return CloseDetailViewMsg{} }
 		case key.Matches(msg, d.keys.Quit) && msg.String() == "q":
@@ -208,6 +292,23 @@ func (d *DetailView) buildContent() {
 		sections = append(sections, relatedSection)
 	}
 
+	// Development section (pull requests, commits, branches)
+	if len(d.item.DevelopmentLinks) > 0 {
+		d.devOffset = len(strings.Split(strings.Join(sections, "\n\n"), "\n")) + 1
+		devSection := d.styles.DetailSection.
+			Width(d.width - 6).
+			Render(fmt.Sprintf("DEVELOPMENT (%d)\n%s", len(d.item.DevelopmentLinks), d.renderDevelopment()))
+		sections = append(sections, devSection)
+	}
+
+	// Attachments section
+	if len(d.item.Attachments) > 0 {
+		attachmentsSection := d.styles.DetailSection.
+			Width(d.width - 6).
+			Render(fmt.Sprintf("ATTACHMENTS (%d)\n%s", len(d.item.Attachments), d.renderAttachments()))
+		sections = append(sections, attachmentsSection)
+	}
+
 	// Description section
 	if d.item.Description != "" {
 		desc := d.renderMarkdown(d.item.Description, d.width-10)
@@ -248,11 +349,19 @@ func (d *DetailView) buildContent() {
 	}
 
 	// Comments section
+	d.commentOffsets = nil
 	if len(d.item.Comments) > 0 {
-		commentsContent := d.renderComments()
+		// Header lines of each comment, relative to the section content
+		commentsContent, offsets := d.renderComments()
 		commentsSection := d.styles.DetailSection.
 			Width(d.width - 6).
 			Render(fmt.Sprintf("COMMENTS (%d)\n%s", len(d.item.Comments), commentsContent))
+
+		// Section starts after all previous sections, plus its border/padding and title
+		start := len(strings.Split(strings.Join(sections, "\n\n"), "\n")) + 1 + d.styles.DetailSection.GetBorderTopSize() + d.styles.DetailSection.GetPaddingTop() + 1
+		for _, off := range offsets {
+			d.commentOffsets = append(d.commentOffsets, start+off)
+		}
 		sections = append(sections, commentsSection)
 	} else if d.item.CommentCount > 0 {
 		// Show count but comments not loaded
@@ -438,17 +547,165 @@ func (d *DetailView) renderRelatedLinks() string {
 	return strings.Join(lines, "\n")
 }
 
-func (d *DetailView) renderComments() string {
+func (d *DetailView) renderDevelopment() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+
+	var lines []string
+	for i, link := range d.item.DevelopmentLinks {
+		var head string
+		var details []string
+		switch link.Kind {
+		case models.DevelopmentPullRequest:
+			head = "PR " + link.ShortRef()
+			var status []string
+			if link.Status != "" {
+				status = append(status, prStatusStyle(link.Status).Render(link.Status))
+			}
+			if link.IsDraft {
+				status = append(status, "draft")
+			}
+			if link.MergeStatus != "" && link.MergeStatus != "notSet" && link.Status == "active" {
+				status = append(status, mergeStatusStyle(link.MergeStatus).Render("merge "+link.MergeStatus))
+			}
+			if link.Repository != "" {
+				status = append(status, link.Repository)
+			}
+			if len(status) > 0 {
+				details = append(details, strings.Join(status, mutedStyle.Render(" · ")))
+			}
+			if len(link.Reviewers) > 0 {
+				var reviewers []string
+				for _, r := range link.Reviewers {
+					reviewers = append(reviewers, r.Name+" ("+r.VoteLabel()+")")
+				}
+				details = append(details, mutedStyle.Render("Reviewers: ")+strings.Join(reviewers, ", "))
+			}
+		case models.DevelopmentCommit:
+			head = "Commit " + link.ShortRef()
+			var info []string
+			if link.Author != "" {
+				info = append(info, link.Author)
+			}
+			if !link.Date.IsZero() {
+				info = append(info, link.Date.Local().Format("2006-01-02"))
+			}
+			if link.Repository != "" {
+				info = append(info, link.Repository)
+			}
+			if len(info) > 0 {
+				details = append(details, mutedStyle.Render(strings.Join(info, " · ")))
+			}
+		case models.DevelopmentBranch:
+			head = "Branch " + link.Ref
+			if link.Repository != "" {
+				details = append(details, mutedStyle.Render(link.Repository))
+			}
+		}
+		if link.Title != "" {
+			head += " " + link.Title
+		}
+
+		if i == d.selectedDev {
+			lines = append(lines, selectedStyle.Render("▸ "+head))
+		} else {
+			lines = append(lines, "  "+head)
+		}
+		for _, detail := range details {
+			lines = append(lines, "    "+detail)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// prStatusStyle colors a pull request status
+func prStatusStyle(status string) lipgloss.Style {
+	switch status {
+	case "active":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#3B82F6"))
+	case "completed":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	}
+}
+
+// mergeStatusStyle colors a pull request merge status
+func mergeStatusStyle(status string) lipgloss.Style {
+	switch status {
+	case "succeeded":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+	case "queued":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+	}
+}
+
+// selectDevLink selects the next development link, wrapping around, and scrolls to the section
+func (d *DetailView) selectDevLink() {
+	if d.item == nil || len(d.item.DevelopmentLinks) == 0 {
+		d.notice = "No linked pull requests, commits or branches"
+		return
+	}
+	d.selectedDev = (d.selectedDev + 1) % len(d.item.DevelopmentLinks)
+	d.contentBuilt = false
+	d.buildContent()
+	d.scrollOffset = d.devOffset
+}
+
+func (d *DetailView) renderAttachments() string {
+	var lines []string
+	for _, att := range d.item.Attachments {
+		line := fmt.Sprintf("  %s (%s)", att.Name, att.FormattedSize())
+		if att.UploadedBy != "" {
+			line += " by " + att.UploadedBy
+		}
+		if !att.AddedDate.IsZero() {
+			line += " on " + att.AddedDate.Local().Format("2006-01-02")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderComments renders the comment list and returns the line of each comment header
+func (d *DetailView) renderComments() (string, []int) {
 	var lines []string
+	var offsets []int
 	maxWidth := d.width - 12
 	if maxWidth < 40 {
 		maxWidth = 40
 	}
 
 	for i, comment := range d.item.Comments {
+		offsets = append(offsets, len(lines))
+
 		// Comment header with author and date
-		header := fmt.Sprintf("  %s • %s", comment.CreatedBy, comment.CreatedDate.Format("2006-01-02 15:04"))
-		lines = append(lines, d.styles.DetailLabel.Render(header))
+		header := fmt.Sprintf("%s • %s", comment.CreatedBy, comment.CreatedDate.Format("2006-01-02 15:04"))
+		if comment.ModifiedDate.After(comment.CreatedDate) {
+			header += " (edited)"
+		}
+		if d.isOwnComment(comment) {
+			header += " (you)"
+		}
+		if i == d.selectedComment {
+			selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+			lines = append(lines, selectedStyle.Render("▸ "+header))
+		} else {
+			lines = append(lines, d.styles.DetailLabel.Render("  "+header))
+		}
+
+		// Markdown comments get full rendering
+		if comment.Format == models.CommentFormatMarkdown {
+			for _, line := range strings.Split(d.renderMarkdown(comment.Text, maxWidth), "\n") {
+				lines = append(lines, "  "+line)
+			}
+			if i < len(d.item.Comments)-1 {
+				lines = append(lines, "")
+			}
+			continue
+		}
 
 		// Comment body - preserve original line breaks, then wrap long lines
 		// Replace \r\n and \r with \n, then split
@@ -472,7 +729,73 @@ func (d *DetailView) renderComments() string {
 			lines = append(lines, "")
 		}
 	}
-	return strings.Join(lines, "\n")
+	return strings.Join(lines, "\n"), offsets
+}
+
+// isOwnComment returns true if the comment was written by the signed-in user
+func (d *DetailView) isOwnComment(comment models.Comment) bool {
+	return d.currentUserID != "" && comment.CreatedByID == d.currentUserID
+}
+
+// selectComment selects a comment by index (clamped) and scrolls it into view
+func (d *DetailView) selectComment(index int) {
+	if d.item == nil || len(d.item.Comments) == 0 {
+		d.notice = "No comments"
+		return
+	}
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(d.item.Comments) {
+		index = len(d.item.Comments) - 1
+	}
+	d.selectedComment = index
+	d.contentBuilt = false
+	d.buildContent()
+
+	if index < len(d.commentOffsets) {
+		d.scrollOffset = d.commentOffsets[index]
+	}
+}
+
+// SelectedComment returns the selected comment, or nil if none is selected
+func (d *DetailView) SelectedComment() *models.Comment {
+	if d.item == nil || d.selectedComment < 0 || d.selectedComment >= len(d.item.Comments) {
+		return nil
+	}
+	return &d.item.Comments[d.selectedComment]
+}
+
+// SetComments replaces the comment list in place, keeping the scroll position
+func (d *DetailView) SetComments(comments []models.Comment) {
+	if d.item == nil {
+		return
+	}
+	d.item.Comments = comments
+	d.item.CommentCount = len(comments)
+	if d.selectedComment >= len(comments) {
+		d.selectedComment = len(comments) - 1
+	}
+	d.contentBuilt = false
+}
+
+// SetCurrentUserID sets the identity ID of the signed-in user, used to find own comments
+func (d *DetailView) SetCurrentUserID(id string) {
+	d.currentUserID = id
+	d.contentBuilt = false
+}
+
+// SetNotice shows a one-off message in the status bar
+func (d *DetailView) SetNotice(notice string) {
+	d.notice = notice
+}
+
+// ItemID returns the ID of the displayed work item, or 0 if none
+func (d *DetailView) ItemID() int {
+	if d.item == nil {
+		return 0
+	}
+	return d.item.ID
 }
 
 func (d *DetailView) renderMarkdown(content string, width int) string {
@@ -500,7 +823,12 @@ func (d *DetailView) renderStatusBar() string {
 		}
 		scrollInfo = fmt.Sprintf("  [%d%%]", scrollPercent)
 	}
-	help := "Esc Back  Enter Open in browser  j/k Scroll  g/G Top/Bottom  PgUp/PgDn" + scrollInfo
+	help := "Esc Back  Enter Open in browser  j/k Scroll  g/G Top/Bottom  PgUp/PgDn  c Comment  [/] Select comment  e/d Edit/Delete  L Links  A Attachments  H History  p/o Select/Open PR" + scrollInfo
+	if d.confirmDelete {
+		help = "Delete the selected comment? y to confirm, any other key to cancel"
+	} else if d.notice != "" {
+		help = d.notice
+	}
 	return d.styles.StatusBar.
 		Width(d.width).
 		Render(help)
@@ -509,12 +837,48 @@ func (d *DetailView) renderStatusBar() string {
 // SetItem sets the work item to display
 func (d *DetailView) SetItem(item *models.WorkItem) {
 	d.item = item
+	d.selectedComment = -1
+	d.selectedDev = -1
+	d.confirmDelete = false
+	d.notice = ""
 	d.scrollOffset = 0
 	d.maxScroll = 0
 	d.contentBuilt = false
 	d.contentLines = nil
 }
 
+// ReplaceItem swaps in a reloaded version of the displayed work item, keeping
+// the scroll position and comment selection
+func (d *DetailView) ReplaceItem(item *models.WorkItem) {
+	if d.item == nil || item == nil || d.item.ID != item.ID {
+		d.SetItem(item)
+		return
+	}
+	d.item = item
+	if d.selectedComment >= len(item.Comments) {
+		d.selectedComment = len(item.Comments) - 1
+	}
+	if d.selectedDev >= len(item.DevelopmentLinks) {
+		d.selectedDev = -1
+	}
+	d.contentBuilt = false
+}
+
+// SetAttachmentUploaders sets who added each attachment, keyed by attachment URL
+func (d *DetailView) SetAttachmentUploaders(uploaders map[string]string) {
+	if d.item == nil {
+		return
+	}
+	item := *d.item
+	item.Attachments = make([]models.Attachment, len(d.item.Attachments))
+	for i, att := range d.item.Attachments {
+		att.UploadedBy = uploaders[att.URL]
+		item.Attachments[i] = att
+	}
+	d.item = &item
+	d.contentBuilt = false
+}
+
 // SetSize sets the size of the detail view
 func (d *DetailView) SetSize(width, height int) {
 	// Invalidate content if size changed
@@ -527,3 +891,31 @@ func (d *DetailView) SetSize(width, height int) {
 
 // CloseDetailViewMsg is sent when the detail view should be closed
 type CloseDetailViewMsg struct{}
+
+// ComposeCommentMsg is sent when the user wants to write or edit a comment
+type ComposeCommentMsg struct {
+	WorkItemID int
+	CommentID  int    // Zero for a new comment
+	Text       string // Current text when editing
+}
+
+// DeleteCommentRequestMsg is sent when the user confirms deleting a comment
+type DeleteCommentRequestMsg struct {
+	WorkItemID int
+	CommentID  int
+}
+
+// ManageLinksMsg is sent when the user wants to add or remove links
+type ManageLinksMsg struct {
+	Item models.WorkItem
+}
+
+// ManageAttachmentsMsg is sent when the user wants to download or upload attachments
+type ManageAttachmentsMsg struct {
+	Item models.WorkItem
+}
+
+// OpenURLMsg is sent when a URL should be opened in the browser
+type OpenURLMsg struct {
+	URL string
+}
@@ -0,0 +1,495 @@
+package components
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// editableField is a field offered in the field editor
+type editableField struct {
+	ref   string
+	label string
+}
+
+// editableFields lists the fields the editor can change, in display order
+var editableFields = []editableField{
+	{ref: "System.Title", label: "Title"},
+	{ref: "Microsoft.VSTS.Common.Priority", label: "Priority"},
+	{ref: "System.Tags", label: "Tags"},
+	{ref: "Microsoft.VSTS.Scheduling.StoryPoints", label: "Story Points"},
+	{ref: "Microsoft.VSTS.Scheduling.RemainingWork", label: "Remaining Work"},
+	{ref: "Microsoft.VSTS.Scheduling.CompletedWork", label: "Completed Work"},
+	{ref: "Microsoft.VSTS.Common.Severity", label: "Severity"},
+	{ref: "Microsoft.VSTS.Common.Activity", label: "Activity"},
+	{ref: "Microsoft.VSTS.Common.ValueArea", label: "Value Area"},
+	{ref: "System.Description", label: "Description"},
+	{ref: "Microsoft.VSTS.Common.AcceptanceCriteria", label: "Acceptance Criteria"},
+	{ref: "Microsoft.VSTS.TCM.ReproSteps", label: "Repro Steps"},
+}
+
+// FieldEditor is a modal for editing fields of a work item
+type FieldEditor struct {
+	visible    bool
+	item       *models.WorkItem
+	defs       map[string]models.FieldDefinition
+	defsLoaded bool
+	fields     []editableField
+	pending    map[string]FieldChange
+	cursor     int
+	focus      string // Field to put the cursor on once the fields are known
+	editing    bool
+	input      textinput.Model
+	err        error
+	styles     theme.Styles
+	keys       theme.KeyMap
+	width      int
+	height     int
+}
+
+// NewFieldEditor creates a new field editor modal
+func NewFieldEditor(styles theme.Styles, keys theme.KeyMap) FieldEditor {
+	ti := textinput.New()
+	ti.CharLimit = 255
+	ti.Width = 40
+
+	return FieldEditor{
+		input:  ti,
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the modal
+func (m FieldEditor) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m FieldEditor) Update(msg tea.Msg) (FieldEditor, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.editing {
+		return m.updateEditing(keyMsg)
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		m.visible = false
+		return m, func() tea.Msg { return ModalClosedMsg{} }
+	case key.Matches(keyMsg, m.keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, m.keys.Down):
+		if m.cursor < len(m.fields)-1 {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, m.keys.Save):
+		return m, m.save()
+	case key.Matches(keyMsg, m.keys.Select):
+		if m.cursor < len(m.fields) && m.defsLoaded {
+			field := m.fields[m.cursor]
+			change, hasPending := m.pending[field.ref]
+
+			// Rich text is edited as markdown in $EDITOR
+			if m.definition(field).Type == models.FieldTypeHTML {
+				req := EditLongTextMsg{
+					Item:    *m.item,
+					Field:   field.ref,
+					Label:   field.label,
+					Text:    change.Ours,
+					Pending: hasPending,
+				}
+				return m, func() tea.Msg { return req }
+			}
+
+			value := m.item.FieldValue(field.ref)
+			if hasPending {
+				value = change.Ours
+			}
+			m.editing = true
+			m.err = nil
+			m.input.SetValue(value)
+			m.input.CursorEnd()
+			m.input.Focus()
+			return m, textinput.Blink
+		}
+	}
+
+	return m, nil
+}
+
+// updateEditing handles keys while a field value is being typed
+func (m FieldEditor) updateEditing(msg tea.KeyMsg) (FieldEditor, tea.Cmd) {
+	field := m.fields[m.cursor]
+	def := m.definition(field)
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.editing = false
+		m.err = nil
+		m.input.Blur()
+		return m, nil
+	case tea.KeyTab:
+		// Cycle through allowed values
+		if len(def.AllowedValues) > 0 {
+			next := 0
+			for i, v := range def.AllowedValues {
+				if strings.EqualFold(v, m.input.Value()) {
+					next = (i + 1) % len(def.AllowedValues)
+					break
+				}
+			}
+			m.input.SetValue(def.AllowedValues[next])
+			m.input.CursorEnd()
+		}
+		return m, nil
+	case tea.KeyEnter:
+		value, err := def.Parse(m.input.Value())
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+
+		ours := ""
+		if value != nil {
+			ours = formatFieldValue(value)
+		}
+		base := m.item.FieldValue(field.ref)
+		if value == nil && isUnsetValue(def.Type, base) {
+			// Nothing to clear, and removing a field that isn't set fails
+			ours = base
+		}
+		if ours == base {
+			delete(m.pending, field.ref)
+		} else {
+			m.pending[field.ref] = FieldChange{
+				Field: field.ref,
+				Label: field.label,
+				Base:  base,
+				Ours:  ours,
+				Value: value,
+			}
+		}
+
+		m.editing = false
+		m.err = nil
+		m.input.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// save emits the pending changes in display order
+func (m FieldEditor) save() tea.Cmd {
+	if len(m.pending) == 0 || m.item == nil {
+		return func() tea.Msg { return ModalClosedMsg{} }
+	}
+
+	changes := make([]FieldChange, 0, len(m.pending))
+	for _, f := range m.fields {
+		if change, ok := m.pending[f.ref]; ok {
+			changes = append(changes, change)
+		}
+	}
+
+	item := *m.item
+	return func() tea.Msg {
+		return FieldUpdateRequestMsg{Item: item, Changes: changes}
+	}
+}
+
+// definition returns the server definition of a field, or a built-in fallback
+func (m FieldEditor) definition(field editableField) models.FieldDefinition {
+	if def, ok := m.defs[field.ref]; ok {
+		if def.Name == "" {
+			def.Name = field.label
+		}
+		return def
+	}
+	return models.FieldDefinition{
+		ReferenceName: field.ref,
+		Name:          field.label,
+		Type:          models.KnownFieldType(field.ref),
+		Required:      field.ref == "System.Title",
+	}
+}
+
+// View renders the modal
+func (m FieldEditor) View() string {
+	if !m.visible || m.item == nil {
+		return ""
+	}
+
+	modalWidth := 64
+	labelW := 21
+
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Render("Edit Fields")
+	itemInfo := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF")).
+		Render("#" + itoa(m.item.ID) + " " + truncateStr(m.item.Title, 45))
+	b.WriteString(title + "\n")
+	b.WriteString(itemInfo + "\n\n")
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	if !m.defsLoaded {
+		b.WriteString(mutedStyle.Render("Loading field definitions...") + "\n")
+	} else if len(m.fields) == 0 {
+		b.WriteString(mutedStyle.Render("No editable fields for this type") + "\n")
+	}
+
+	changedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+	for i, field := range m.fields {
+		if !m.defsLoaded {
+			break
+		}
+
+		cursor := "  "
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+		if i == m.cursor {
+			cursor = "▸ "
+			labelStyle = labelStyle.Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+		}
+
+		value := displayValue(m.item.FieldValue(field.ref))
+		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F9FAFB"))
+		if change, ok := m.pending[field.ref]; ok {
+			value = displayValue(change.Ours) + " *"
+			valueStyle = changedStyle
+		}
+
+		line := cursor + labelStyle.Render(padRight(field.label, labelW))
+		if m.editing && i == m.cursor {
+			line += m.input.View()
+		} else {
+			line += valueStyle.Render(truncateStr(value, modalWidth-labelW-8))
+		}
+		b.WriteString(line + "\n")
+	}
+
+	// Allowed values hint for the field being edited
+	if m.editing {
+		def := m.definition(m.fields[m.cursor])
+		if len(def.AllowedValues) > 0 {
+			hint := "Allowed: " + strings.Join(def.AllowedValues, ", ")
+			b.WriteString("\n" + mutedStyle.Render(truncateStr(hint, modalWidth-6)) + "\n")
+		}
+	}
+
+	if m.err != nil {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+		b.WriteString("\n" + errStyle.Render(m.err.Error()) + "\n")
+	}
+
+	b.WriteString("\n")
+	if m.editing {
+		b.WriteString(mutedStyle.Render("Enter: set  Tab: next allowed value  Esc: cancel"))
+	} else {
+		b.WriteString(mutedStyle.Render("Enter: edit  " + m.keys.Save.Help().Key + ": save  Esc: discard"))
+	}
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Background(lipgloss.Color("#1F2937"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(b.String()))
+}
+
+// SetItem sets the work item to edit and resets pending changes
+func (m *FieldEditor) SetItem(item *models.WorkItem) {
+	m.item = item
+	m.pending = make(map[string]FieldChange)
+	m.cursor = 0
+	m.focus = ""
+	m.editing = false
+	m.err = nil
+	m.defs = nil
+	m.defsLoaded = false
+	m.fields = editableFields
+}
+
+// SetDefinitions sets the field definitions of the item's type. Only fields defined
+// for the type are offered; nil falls back to the built-in field list.
+func (m *FieldEditor) SetDefinitions(defs []models.FieldDefinition) {
+	m.defsLoaded = true
+	if defs == nil {
+		m.fields = editableFields
+		return
+	}
+
+	m.defs = make(map[string]models.FieldDefinition, len(defs))
+	for _, d := range defs {
+		m.defs[d.ReferenceName] = d
+	}
+
+	m.fields = nil
+	for _, f := range editableFields {
+		if _, ok := m.defs[f.ref]; ok {
+			m.fields = append(m.fields, f)
+		}
+	}
+	if m.cursor >= len(m.fields) {
+		m.cursor = 0
+	}
+	m.moveToFocus()
+}
+
+// Restore brings back changes the server rejected so they can be fixed and saved
+// again, with the cursor on the rejected field. It reports whether that field can
+// be edited here; if not, nothing is restored.
+func (m *FieldEditor) Restore(changes []FieldChange, field string, err error) bool {
+	if !isEditableField(field) {
+		return false
+	}
+	for _, change := range changes {
+		if isEditableField(change.Field) {
+			m.pending[change.Field] = change
+		}
+	}
+	m.focus = field
+	m.moveToFocus()
+	m.err = err
+	return true
+}
+
+// moveToFocus puts the cursor on the focus field, if it is offered
+func (m *FieldEditor) moveToFocus() {
+	for i, f := range m.fields {
+		if f.ref == m.focus {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// isUnsetValue reports whether a displayed value means the field isn't set. Numeric
+// fields show 0 when unset, so a 0 can't be told apart and is left as it is.
+func isUnsetValue(fieldType models.FieldType, value string) bool {
+	if fieldType == models.FieldTypeInteger || fieldType == models.FieldTypeDouble {
+		return value == "" || value == "0"
+	}
+	return value == ""
+}
+
+// isEditableField reports whether the editor offers a field
+func isEditableField(ref string) bool {
+	for _, f := range editableFields {
+		if f.ref == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// SetLongText records a rich text field edited in $EDITOR as a pending change.
+// markdown is what the user wrote, html is the value sent to the server.
+func (m *FieldEditor) SetLongText(field, label, markdown, html string) {
+	if m.item == nil {
+		return
+	}
+
+	change := FieldChange{
+		Field: field,
+		Label: label,
+		Base:  m.item.FieldValue(field),
+		Ours:  markdown,
+		Value: html,
+	}
+	if markdown == "" {
+		if change.Base == "" {
+			// Nothing to clear, and removing a field that isn't set fails
+			delete(m.pending, field)
+			return
+		}
+		change.Value = nil
+	}
+	m.pending[field] = change
+}
+
+// SetError shows an error in the editor
+func (m *FieldEditor) SetError(err error) {
+	m.err = err
+}
+
+// ItemType returns the type of the item being edited
+func (m *FieldEditor) ItemType() string {
+	if m.item == nil {
+		return ""
+	}
+	return string(m.item.Type)
+}
+
+// SetVisible sets the visibility
+func (m *FieldEditor) SetVisible(visible bool) {
+	m.visible = visible
+	if !visible {
+		m.input.Blur()
+	}
+}
+
+// IsVisible returns whether the modal is visible
+func (m *FieldEditor) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal container size
+func (m *FieldEditor) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// formatFieldValue formats a parsed field value the way WorkItem.FieldValue does
+func formatFieldValue(v interface{}) string {
+	switch v := v.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return itoa(v)
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case string:
+		return v
+	}
+	return ""
+}
+
+// EditLongTextMsg is sent when a rich text field should be edited in $EDITOR
+type EditLongTextMsg struct {
+	Item    models.WorkItem
+	Field   string
+	Label   string
+	Text    string // Markdown of an earlier pending edit
+	Pending bool   // Whether Text should be used instead of the server's value
+}
+
+// FieldUpdateRequestMsg is sent when the user saves field changes
+type FieldUpdateRequestMsg struct {
+	Item    models.WorkItem
+	Changes []FieldChange
+}
@@ -0,0 +1,332 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// flowRanges are the date ranges, in days, the flow view cycles through
+var flowRanges = []int{14, 30, 60, 90}
+
+// flowColors are the colors of the state categories in the flow diagram
+var flowColors = map[string]string{
+	"Proposed":   "#60A5FA",
+	"InProgress": "#F59E0B",
+	"Resolved":   "#06B6D4",
+	"Completed":  "#10B981",
+}
+
+// FlowView is the fullscreen cumulative flow diagram of a sprint or of the last days
+type FlowView struct {
+	iterations []models.Iteration // Started sprints with dates
+	index      int                // Shown sprint
+	bySprint   bool               // Show a sprint rather than the last days
+	rangeIndex int                // Index into flowRanges
+	days       []models.FlowDay
+	loading    bool
+	errMsg     string
+	styles     theme.Styles
+	keys       theme.KeyMap
+	width      int
+	height     int
+}
+
+// NewFlowView creates a new cumulative flow view
+func NewFlowView(styles theme.Styles, keys theme.KeyMap) FlowView {
+	return FlowView{
+		rangeIndex: 1,
+		styles:     styles,
+		keys:       keys,
+	}
+}
+
+// Init initializes the flow view
+func (f FlowView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the flow view
+func (f *FlowView) Update(msg tea.Msg) (*FlowView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return f, nil
+	}
+
+	reload := func() tea.Msg { return ReloadFlowMsg{} }
+	switch {
+	case key.Matches(keyMsg, f.keys.Back):
+		return f, func() tea.Msg { return CloseFlowMsg{} }
+	case key.Matches(keyMsg, f.keys.Quit) && keyMsg.String() == "q":
+		return f, func() tea.Msg { return CloseFlowMsg{} }
+	case key.Matches(keyMsg, f.keys.Refresh):
+		return f, reload
+	case keyMsg.String() == "s" && len(f.iterations) > 0:
+		f.bySprint = !f.bySprint
+		return f, reload
+	case f.bySprint && keyMsg.String() == "]" && f.index < len(f.iterations)-1:
+		f.index++
+		return f, reload
+	case f.bySprint && keyMsg.String() == "[" && f.index > 0:
+		f.index--
+		return f, reload
+	case !f.bySprint && (keyMsg.String() == "+" || keyMsg.String() == "=") && f.rangeIndex < len(flowRanges)-1:
+		f.rangeIndex++
+		return f, reload
+	case !f.bySprint && keyMsg.String() == "-" && f.rangeIndex > 0:
+		f.rangeIndex--
+		return f, reload
+	}
+
+	return f, nil
+}
+
+// Scope returns the dates to chart, and the sprint if a sprint is shown
+func (f *FlowView) Scope() (start, end time.Time, sprint *models.Iteration) {
+	end = time.Now()
+	if f.bySprint && f.index < len(f.iterations) {
+		sprint = &f.iterations[f.index]
+		if sprint.FinishDate.Before(end) {
+			end = sprint.FinishDate
+		}
+		return sprint.StartDate, end, sprint
+	}
+	return end.AddDate(0, 0, -flowRanges[f.rangeIndex]+1), end, nil
+}
+
+// View renders the flow view
+func (f *FlowView) View() string {
+	titleBar := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Width(f.width).
+		Render("Cumulative Flow")
+
+	bodyHeight := f.height - 2
+	if bodyHeight < 3 {
+		bodyHeight = 3
+	}
+
+	var message string
+	switch {
+	case f.loading:
+		message = f.styles.Subtitle.Render("Loading state history...")
+	case f.errMsg != "":
+		message = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(f.errMsg)
+	case len(f.days) == 0:
+		message = f.styles.Subtitle.Render("No days to show")
+	}
+	if message != "" {
+		body := f.styles.PanelActive.Width(f.width - 2).Height(bodyHeight - 2).Render(f.renderScope() + "\n\n" + message)
+		return lipgloss.JoinVertical(lipgloss.Left, titleBar, body, f.renderStatusBar())
+	}
+
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		f.renderScope(),
+		f.renderLegend(),
+		"",
+		f.renderChart(bodyHeight-3),
+	)
+	body = lipgloss.NewStyle().Width(f.width).Height(bodyHeight).MaxHeight(bodyHeight).Render(body)
+	return lipgloss.JoinVertical(lipgloss.Left, titleBar, body, f.renderStatusBar())
+}
+
+// renderScope renders what the diagram covers
+func (f *FlowView) renderScope() string {
+	nameStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#06B6D4"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	start, end, sprint := f.Scope()
+	if sprint != nil {
+		return " " + nameStyle.Render(sprint.DisplayName()) + mutedStyle.Render(" · "+sprint.DateRange())
+	}
+	return " " + nameStyle.Render(fmt.Sprintf("Last %d days", flowRanges[f.rangeIndex])) +
+		mutedStyle.Render(" · "+start.Format("2 Jan")+" - "+end.Format("2 Jan"))
+}
+
+// renderLegend renders the categories with their colors and latest counts
+func (f *FlowView) renderLegend() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	parts := []string{}
+	var last models.FlowDay
+	if len(f.days) > 0 {
+		last = f.days[len(f.days)-1]
+	}
+	for _, category := range models.TaskboardCategories {
+		swatch := lipgloss.NewStyle().Foreground(lipgloss.Color(flowColors[category])).Render("█")
+		parts = append(parts, swatch+" "+models.CategoryLabel(category)+mutedStyle.Render(fmt.Sprintf(" %d", last.Counts[category])))
+	}
+	wip := last.Counts["InProgress"] + last.Counts["Resolved"]
+	return " " + strings.Join(parts, "   ") + mutedStyle.Render(fmt.Sprintf("   · %d in progress now", wip))
+}
+
+// renderChart renders the counts as stacked areas, completed at the bottom
+func (f *FlowView) renderChart(height int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	maxTotal := 0
+	for _, day := range f.days {
+		if t := day.Total(); t > maxTotal {
+			maxTotal = t
+		}
+	}
+	if maxTotal == 0 {
+		return mutedStyle.Render(" No items in this range")
+	}
+
+	rows := height - 2
+	if rows < 3 {
+		rows = 3
+	}
+
+	// One column per day, or a sample of the days if they don't fit
+	plotWidth := f.width - 9
+	if plotWidth < 10 {
+		plotWidth = 10
+	}
+	colWidth := plotWidth / len(f.days)
+	if colWidth > 3 {
+		colWidth = 3
+	}
+	cols := len(f.days)
+	if colWidth < 1 {
+		colWidth = 1
+		cols = plotWidth
+	}
+	dayAt := func(col int) models.FlowDay {
+		if cols == len(f.days) || cols == 1 {
+			return f.days[col]
+		}
+		return f.days[col*(len(f.days)-1)/(cols-1)]
+	}
+
+	// Bottom to top
+	stack := []string{"Completed", "Resolved", "InProgress", "Proposed"}
+	styles := make(map[string]lipgloss.Style, len(stack))
+	for _, category := range stack {
+		styles[category] = lipgloss.NewStyle().Foreground(lipgloss.Color(flowColors[category]))
+	}
+
+	var lines []string
+	for r := 0; r < rows; r++ {
+		level := float64(maxTotal) * (float64(rows-r) - 0.5) / float64(rows)
+
+		axis := "      "
+		switch r {
+		case 0:
+			axis = fmt.Sprintf("%5d ", maxTotal)
+		case rows - 1:
+			axis = fmt.Sprintf("%5d ", 0)
+		}
+
+		var b strings.Builder
+		b.WriteString(mutedStyle.Render(axis + "│"))
+		for c := 0; c < cols; c++ {
+			day := dayAt(c)
+			cell := strings.Repeat(" ", colWidth)
+			sum := 0
+			for _, category := range stack {
+				sum += day.Counts[category]
+				if float64(sum) >= level {
+					cell = styles[category].Render(strings.Repeat("█", colWidth))
+					break
+				}
+			}
+			b.WriteString(cell)
+		}
+		lines = append(lines, b.String())
+	}
+
+	// First, middle and last date under the chart
+	width := cols * colWidth
+	lines = append(lines, mutedStyle.Render("      └"+strings.Repeat("─", width)))
+	first := f.days[0].Date.Format("2 Jan")
+	middle := f.days[len(f.days)/2].Date.Format("2 Jan")
+	last := f.days[len(f.days)-1].Date.Format("2 Jan")
+	labels := []rune(strings.Repeat(" ", width+len(last)))
+	copy(labels, []rune(first))
+	if mid := width/2 - len(middle)/2; mid > len(first) {
+		copy(labels[mid:], []rune(middle))
+	}
+	if end := width - len(last); end > width/2+len(middle) {
+		copy(labels[end:], []rune(last))
+	}
+	lines = append(lines, mutedStyle.Render("       "+strings.TrimRight(string(labels), " ")))
+
+	return strings.Join(lines, "\n")
+}
+
+func (f *FlowView) renderStatusBar() string {
+	help := "Esc Back  +/- Days  s Sprint  Ctrl+r Reload"
+	if f.bySprint {
+		help = "Esc Back  [/] Sprint  s Last days  Ctrl+r Reload"
+	}
+	return f.styles.StatusBar.
+		Width(f.width).
+		Render(help)
+}
+
+// SetIterations sets the sprints to choose from, keeping those that have started,
+// and selects the one with the given path or else the current one
+func (f *FlowView) SetIterations(iterations []models.Iteration, path string) {
+	f.iterations = nil
+	for _, it := range iterations {
+		if !it.StartDate.IsZero() && !it.FinishDate.IsZero() && !it.IsFuture() {
+			f.iterations = append(f.iterations, it)
+		}
+	}
+	f.index = 0
+	for i, it := range f.iterations {
+		if it.IsCurrent() {
+			f.index = i
+		}
+	}
+	for i, it := range f.iterations {
+		if it.Path == path {
+			f.index = i
+		}
+	}
+	if len(f.iterations) == 0 {
+		f.bySprint = false
+	}
+}
+
+// SetLoading marks the view as loading
+func (f *FlowView) SetLoading() {
+	f.loading = true
+	f.errMsg = ""
+}
+
+// SetFlow sets the counts per day
+func (f *FlowView) SetFlow(days []models.FlowDay) {
+	f.days = days
+	f.loading = false
+	f.errMsg = ""
+}
+
+// SetError shows an error, ending the loading state
+func (f *FlowView) SetError(err string) {
+	f.errMsg = err
+	f.loading = false
+}
+
+// SetSize sets the size of the flow view
+func (f *FlowView) SetSize(width, height int) {
+	f.width = width
+	f.height = height
+}
+
+// CloseFlowMsg is sent when the flow view should be closed
+type CloseFlowMsg struct{}
+
+// ReloadFlowMsg is sent when the flow should be loaded again, e.g. for another range
+type ReloadFlowMsg struct{}
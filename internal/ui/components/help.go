@@ -64,8 +64,51 @@ func (h HelpPanel) View() string {
 				h.keys.Select,
 				h.keys.Open,
 				h.keys.View,
+				h.keys.EditFields,
+				h.keys.Save,
+				h.keys.NewWorkItem,
+				h.keys.History,
+				h.keys.MoveToSprint,
+				h.keys.WIQLQuery,
 				h.keys.Search,
 				h.keys.Refresh,
+				h.keys.SignIn,
+			},
+		},
+		{
+			title: "Views",
+			bindings: []key.Binding{
+				h.keys.SprintPlanning,
+				h.keys.SprintDashboard,
+				h.keys.Velocity,
+				h.keys.CumulativeFlow,
+				h.keys.CycleTime,
+				h.keys.KanbanBoard,
+				h.keys.Taskboard,
+				h.keys.TreeView,
+			},
+		},
+		{
+			title: "Multi-select",
+			bindings: []key.Binding{
+				h.keys.ToggleMark,
+				h.keys.MarkRange,
+				h.keys.MarkAll,
+				h.keys.BulkActions,
+			},
+		},
+		{
+			title: "Detail View",
+			bindings: []key.Binding{
+				h.keys.AddComment,
+				h.keys.NextComment,
+				h.keys.PrevComment,
+				h.keys.EditComment,
+				h.keys.DeleteComment,
+				h.keys.ManageLinks,
+				h.keys.ManageAttachments,
+				h.keys.NextDevLink,
+				h.keys.OpenDevLink,
 			},
 		},
 		{
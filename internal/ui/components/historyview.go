@@ -0,0 +1,397 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+	"github.com/samuelenocsson/devops-tui/pkg/textdiff"
+)
+
+// historyListWidth is the width of the revision list on the left
+const historyListWidth = 42
+
+// HistoryView is the fullscreen revision history of a work item
+type HistoryView struct {
+	item       *models.WorkItem
+	revisions  []models.WorkItemRevision // Newest first
+	cursor     int
+	listOffset int
+	scroll     int // Scroll offset of the revision details
+	sideBySide bool
+	loading    bool
+	errMsg     string
+	styles     theme.Styles
+	keys       theme.KeyMap
+	width      int
+	height     int
+}
+
+// NewHistoryView creates a new history view
+func NewHistoryView(styles theme.Styles, keys theme.KeyMap) HistoryView {
+	return HistoryView{
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the history view
+func (h HistoryView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the history view
+func (h *HistoryView) Update(msg tea.Msg) (*HistoryView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return h, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, h.keys.Back):
+		return h, func() tea.Msg { return CloseHistoryMsg{} }
+	case key.Matches(keyMsg, h.keys.Quit) && keyMsg.String() == "q":
+		return h, func() tea.Msg { return CloseHistoryMsg{} }
+	case key.Matches(keyMsg, h.keys.Up):
+		h.selectRevision(h.cursor - 1)
+	case key.Matches(keyMsg, h.keys.Down):
+		h.selectRevision(h.cursor + 1)
+	case key.Matches(keyMsg, h.keys.Top):
+		h.selectRevision(0)
+	case key.Matches(keyMsg, h.keys.Bottom):
+		h.selectRevision(len(h.revisions) - 1)
+	case keyMsg.String() == "t":
+		h.sideBySide = !h.sideBySide
+		h.scroll = 0
+	case keyMsg.Type == tea.KeyPgDown:
+		h.scroll += h.pageJump()
+	case keyMsg.Type == tea.KeyPgUp:
+		h.scroll -= h.pageJump()
+		if h.scroll < 0 {
+			h.scroll = 0
+		}
+	}
+
+	return h, nil
+}
+
+// selectRevision moves the cursor, keeping it within the list
+func (h *HistoryView) selectRevision(index int) {
+	if index < 0 || index >= len(h.revisions) {
+		return
+	}
+	h.cursor = index
+	h.scroll = 0
+
+	visible := h.listHeight()
+	if h.cursor < h.listOffset {
+		h.listOffset = h.cursor
+	} else if h.cursor >= h.listOffset+visible {
+		h.listOffset = h.cursor - visible + 1
+	}
+}
+
+// pageJump is half the viewable height
+func (h *HistoryView) pageJump() int {
+	jump := (h.height - 4) / 2
+	if jump < 1 {
+		jump = 1
+	}
+	return jump
+}
+
+// listHeight is the number of revisions that fit in the list
+func (h *HistoryView) listHeight() int {
+	// Title bar, panel borders and status bar
+	height := (h.height - 5) / 2
+	if height < 1 {
+		height = 1
+	}
+	return height
+}
+
+// View renders the history view
+func (h *HistoryView) View() string {
+	if h.item == nil {
+		return ""
+	}
+
+	title := fmt.Sprintf("History of #%d %s", h.item.ID, h.item.Title)
+	titleBar := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Width(h.width).
+		Render(truncateStr(title, h.width-2))
+
+	bodyHeight := h.height - 3
+	if bodyHeight < 1 {
+		bodyHeight = 1
+	}
+	detailWidth := h.width - historyListWidth - 4
+	if detailWidth < 20 {
+		detailWidth = 20
+	}
+
+	var body string
+	switch {
+	case h.loading:
+		body = h.styles.Subtitle.Render("Loading history...")
+	case h.errMsg != "":
+		body = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(h.errMsg)
+	case len(h.revisions) == 0:
+		body = h.styles.Subtitle.Render("No changes recorded")
+	}
+	if body != "" {
+		body = h.styles.PanelActive.Width(h.width - 2).Height(bodyHeight - 2).Render(body)
+	} else {
+		list := h.styles.PanelInactive.
+			Width(historyListWidth - 2).
+			Height(bodyHeight - 2).
+			Render(h.renderList(bodyHeight - 2))
+
+		detailLines := strings.Split(h.renderRevision(detailWidth-2), "\n")
+		maxScroll := len(detailLines) - (bodyHeight - 2)
+		if maxScroll < 0 {
+			maxScroll = 0
+		}
+		if h.scroll > maxScroll {
+			h.scroll = maxScroll
+		}
+		end := h.scroll + bodyHeight - 2
+		if end > len(detailLines) {
+			end = len(detailLines)
+		}
+		detail := h.styles.PanelActive.
+			Width(detailWidth).
+			Height(bodyHeight - 2).
+			Render(strings.Join(detailLines[h.scroll:end], "\n"))
+
+		body = lipgloss.JoinHorizontal(lipgloss.Top, list, detail)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, titleBar, body, h.renderStatusBar())
+}
+
+// renderList renders the revisions, two lines each
+func (h *HistoryView) renderList(height int) string {
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	var lines []string
+	for i := h.listOffset; i < len(h.revisions) && len(lines) < height; i++ {
+		rev := h.revisions[i]
+		header := fmt.Sprintf("Rev %-4d %s", rev.Rev, rev.RevisedDate.Local().Format("2006-01-02 15:04"))
+		summary := truncateStr(rev.RevisedBy+" · "+revisionSummary(rev), historyListWidth-6)
+		if i == h.cursor {
+			lines = append(lines, selectedStyle.Render("▸ "+header), selectedStyle.Render("  "+summary))
+		} else {
+			lines = append(lines, "  "+header, mutedStyle.Render("  "+summary))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// revisionSummary describes a revision in a few words, favoring state changes
+func revisionSummary(rev models.WorkItemRevision) string {
+	if state, ok := rev.FieldChange("System.State"); ok {
+		if state.OldValue == "" {
+			return "created as " + state.NewValue
+		}
+		return "→ " + state.NewValue
+	}
+	if len(rev.Fields) == 1 && len(rev.Links) == 0 {
+		return rev.Fields[0].Label()
+	}
+	if len(rev.Fields) == 0 && len(rev.Links) == 1 {
+		return rev.Links[0].LinkType + " link"
+	}
+	return fmt.Sprintf("%d changes", rev.ChangeCount())
+}
+
+// renderRevision renders the changes of the selected revision
+func (h *HistoryView) renderRevision(width int) string {
+	rev := h.revisions[h.cursor]
+
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#06B6D4"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	oldStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+	newStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Revision %d", rev.Rev)) + "\n")
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("%s, %s", rev.RevisedBy, rev.RevisedDate.Local().Format("Mon 2 Jan 2006 15:04"))) + "\n")
+
+	for _, f := range rev.Fields {
+		b.WriteString("\n" + labelStyle.Render(f.Label()) + "\n")
+		if f.LongText {
+			if h.sideBySide {
+				b.WriteString(renderSideBySide(textdiff.Lines(f.OldValue, f.NewValue), width))
+			} else {
+				b.WriteString(renderInlineDiff(textdiff.Lines(f.OldValue, f.NewValue), width))
+			}
+			continue
+		}
+
+		oldValue, newValue := f.OldValue, f.NewValue
+		if oldValue == "" {
+			oldValue = "(empty)"
+		}
+		if newValue == "" {
+			newValue = "(empty)"
+		}
+		b.WriteString("  " + oldStyle.Render(wordWrap(oldValue, width-4)) + mutedStyle.Render(" → ") +
+			newStyle.Render(wordWrap(newValue, width-4)) + "\n")
+	}
+
+	if len(rev.Links) > 0 {
+		b.WriteString("\n" + labelStyle.Render("Links") + "\n")
+		for _, l := range rev.Links {
+			line := truncateStr(l.LinkType+" "+l.Description, width-4)
+			if l.Added {
+				b.WriteString(newStyle.Render("  + "+line) + "\n")
+			} else {
+				b.WriteString(oldStyle.Render("  - "+line) + "\n")
+			}
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// renderInlineDiff renders diff lines one below the other with +/- markers
+func renderInlineDiff(lines []textdiff.Line, width int) string {
+	var b strings.Builder
+	for _, l := range lines {
+		marker, style := diffMarker(l.Op)
+		for _, part := range wrapDiffLine(l.Text, width-2) {
+			b.WriteString(style.Render(marker+" "+part) + "\n")
+			marker = " "
+		}
+	}
+	return b.String()
+}
+
+// renderSideBySide renders the old text on the left and the new text on the right
+func renderSideBySide(lines []textdiff.Line, width int) string {
+	colWidth := (width - 3) / 2
+	if colWidth < 10 {
+		return renderInlineDiff(lines, width)
+	}
+
+	var b strings.Builder
+	for _, p := range textdiff.SideBySide(lines) {
+		var left, right []string
+		if p.HasOld {
+			left = wrapDiffLine(p.Old, colWidth)
+		}
+		if p.HasNew {
+			right = wrapDiffLine(p.New, colWidth)
+		}
+		_, leftStyle := diffMarker(p.OldOp)
+		_, rightStyle := diffMarker(p.NewOp)
+
+		for i := 0; i < len(left) || i < len(right); i++ {
+			var l, r string
+			if i < len(left) {
+				l = left[i]
+			}
+			if i < len(right) {
+				r = right[i]
+			}
+			b.WriteString(leftStyle.Render(padRight(l, colWidth)) + " │ " + rightStyle.Render(r) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// diffMarker returns the marker and style of a diff line
+func diffMarker(op textdiff.Op) (string, lipgloss.Style) {
+	switch op {
+	case textdiff.Delete:
+		return "-", lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+	case textdiff.Insert:
+		return "+", lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+	default:
+		return " ", lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	}
+}
+
+// wrapDiffLine wraps a line of text to width, keeping blank lines
+func wrapDiffLine(text string, width int) []string {
+	wrapped := wordWrap(text, width)
+	if wrapped == "" {
+		return []string{""}
+	}
+	return strings.Split(wrapped, "\n")
+}
+
+func (h *HistoryView) renderStatusBar() string {
+	mode := "side-by-side"
+	if h.sideBySide {
+		mode = "inline"
+	}
+	help := fmt.Sprintf("Esc Back  j/k Revision  g/G First/Last  PgUp/PgDn Scroll  t %s diff", mode)
+	if len(h.revisions) > 0 {
+		help += fmt.Sprintf("  [%d/%d]", h.cursor+1, len(h.revisions))
+	}
+	return h.styles.StatusBar.
+		Width(h.width).
+		Render(help)
+}
+
+// SetItem shows the history of a work item, marking it as loading
+func (h *HistoryView) SetItem(item *models.WorkItem) {
+	h.item = item
+	h.revisions = nil
+	h.cursor = 0
+	h.listOffset = 0
+	h.scroll = 0
+	h.loading = true
+	h.errMsg = ""
+}
+
+// SetRevisions sets the loaded revisions, given oldest first
+func (h *HistoryView) SetRevisions(revisions []models.WorkItemRevision) {
+	h.revisions = make([]models.WorkItemRevision, len(revisions))
+	for i, rev := range revisions {
+		h.revisions[len(revisions)-1-i] = rev
+	}
+	h.cursor = 0
+	h.listOffset = 0
+	h.scroll = 0
+	h.loading = false
+}
+
+// SetError shows an error instead of the history
+func (h *HistoryView) SetError(err string) {
+	h.errMsg = err
+	h.loading = false
+}
+
+// ItemID returns the ID of the work item shown, or 0 if none
+func (h *HistoryView) ItemID() int {
+	if h.item == nil {
+		return 0
+	}
+	return h.item.ID
+}
+
+// SetSize sets the size of the history view
+func (h *HistoryView) SetSize(width, height int) {
+	h.width = width
+	h.height = height
+}
+
+// ShowHistoryMsg is sent when the history of a work item should be shown
+type ShowHistoryMsg struct {
+	Item models.WorkItem
+}
+
+// CloseHistoryMsg is sent when the history view should be closed
+type CloseHistoryMsg struct{}
@@ -0,0 +1,346 @@
+package components
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// linkTypes are the link types that can be added from the link modal
+var linkTypes = []string{"Parent", "Child", "Related", "Predecessor", "Successor"}
+
+// LinkModal is a modal for adding and removing links of a work item
+type LinkModal struct {
+	visible       bool
+	item          *models.WorkItem
+	candidates    []models.WorkItem // Items from the current list that can be linked
+	filtered      []models.WorkItem
+	cursor        int
+	adding        bool
+	typeIndex     int
+	addCursor     int
+	input         textinput.Model
+	confirmRemove bool
+	errMsg        string
+	styles        theme.Styles
+	keys          theme.KeyMap
+	width         int
+	height        int
+}
+
+// NewLinkModal creates a new link modal
+func NewLinkModal(styles theme.Styles, keys theme.KeyMap) LinkModal {
+	ti := textinput.New()
+	ti.Placeholder = "ID or title..."
+	ti.CharLimit = 100
+	ti.Width = 40
+
+	return LinkModal{
+		input:  ti,
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the modal
+func (m LinkModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m LinkModal) Update(msg tea.Msg) (LinkModal, tea.Cmd) {
+	if !m.visible || m.item == nil {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	m.errMsg = ""
+
+	if m.adding {
+		return m.updateAdding(keyMsg)
+	}
+
+	// Waiting for removal confirmation - anything but "y" cancels
+	if m.confirmRemove {
+		m.confirmRemove = false
+		if keyMsg.String() == "y" && m.cursor < len(m.item.RelatedLinks) {
+			link := m.item.RelatedLinks[m.cursor]
+			req := LinkRemoveRequestMsg{ItemID: m.item.ID, LinkType: link.LinkType, TargetID: link.TargetID}
+			return m, func() tea.Msg { return req }
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		m.visible = false
+		return m, func() tea.Msg { return ModalClosedMsg{} }
+	case key.Matches(keyMsg, m.keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, m.keys.Down):
+		if m.cursor < len(m.item.RelatedLinks)-1 {
+			m.cursor++
+		}
+	case keyMsg.String() == "a":
+		m.adding = true
+		m.input.SetValue("")
+		m.applyFilter()
+		m.input.Focus()
+		return m, textinput.Blink
+	case keyMsg.String() == "d", keyMsg.String() == "x":
+		if m.cursor < len(m.item.RelatedLinks) {
+			m.confirmRemove = true
+		}
+	}
+
+	return m, nil
+}
+
+// updateAdding handles keys while picking the type and target of a new link
+func (m LinkModal) updateAdding(msg tea.KeyMsg) (LinkModal, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.adding = false
+		m.input.Blur()
+		return m, nil
+	case tea.KeyTab:
+		m.typeIndex = (m.typeIndex + 1) % len(linkTypes)
+		return m, nil
+	case tea.KeyShiftTab:
+		m.typeIndex = (m.typeIndex - 1 + len(linkTypes)) % len(linkTypes)
+		return m, nil
+	case tea.KeyUp:
+		if m.addCursor > 0 {
+			m.addCursor--
+		}
+		return m, nil
+	case tea.KeyDown:
+		if m.addCursor < len(m.filtered)-1 {
+			m.addCursor++
+		}
+		return m, nil
+	case tea.KeyEnter:
+		targetID := m.target()
+		if targetID == 0 {
+			return m, nil
+		}
+		req := LinkAddRequestMsg{ItemID: m.item.ID, LinkType: linkTypes[m.typeIndex], TargetID: targetID}
+		return m, func() tea.Msg { return req }
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.applyFilter()
+	return m, cmd
+}
+
+// target returns the ID to link: a typed ID, or else the highlighted candidate
+func (m LinkModal) target() int {
+	value := strings.TrimPrefix(strings.TrimSpace(m.input.Value()), "#")
+	if id, err := strconv.Atoi(value); err == nil && id > 0 {
+		return id
+	}
+	if m.addCursor < len(m.filtered) {
+		return m.filtered[m.addCursor].ID
+	}
+	return 0
+}
+
+func (m *LinkModal) applyFilter() {
+	filter := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(m.input.Value()), "#"))
+	m.filtered = m.filtered[:0]
+	for _, c := range m.candidates {
+		if filter == "" ||
+			strings.HasPrefix(strconv.Itoa(c.ID), filter) ||
+			strings.Contains(strings.ToLower(c.Title), filter) {
+			m.filtered = append(m.filtered, c)
+		}
+	}
+	if m.addCursor >= len(m.filtered) {
+		m.addCursor = 0
+	}
+}
+
+// View renders the modal
+func (m LinkModal) View() string {
+	if !m.visible || m.item == nil {
+		return ""
+	}
+
+	modalWidth := 70
+
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Render("Links")
+	itemInfo := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF")).
+		Render("#" + itoa(m.item.ID) + " " + truncateStr(m.item.Title, 50))
+	b.WriteString(title + "\n")
+	b.WriteString(itemInfo + "\n\n")
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+
+	if m.adding {
+		b.WriteString(m.renderAdd(modalWidth))
+	} else {
+		if len(m.item.RelatedLinks) == 0 {
+			b.WriteString(mutedStyle.Render("No links") + "\n")
+		}
+		for i, link := range m.item.RelatedLinks {
+			line := padRight(link.LinkType, 13) + "#" + padRight(itoa(link.TargetID), 8) +
+				truncateStr(link.Title, modalWidth-32)
+			if i == m.cursor {
+				b.WriteString(selectedStyle.Render("▸ "+line) + "\n")
+			} else {
+				b.WriteString("  " + line + "\n")
+			}
+		}
+
+		b.WriteString("\n")
+		if m.confirmRemove {
+			warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+			b.WriteString(warnStyle.Render("Remove this link? y to confirm"))
+		} else {
+			b.WriteString(mutedStyle.Render("a: add link  d: remove link  Esc: close"))
+		}
+	}
+
+	if m.errMsg != "" {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+		b.WriteString("\n\n" + errStyle.Render(m.errMsg))
+	}
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Background(lipgloss.Color("#1F2937"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(b.String()))
+}
+
+// renderAdd renders the add-link form
+func (m LinkModal) renderAdd(modalWidth int) string {
+	var b strings.Builder
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+
+	// Link type tabs
+	var types []string
+	for i, t := range linkTypes {
+		if i == m.typeIndex {
+			types = append(types, selectedStyle.Render("["+t+"]"))
+		} else {
+			types = append(types, mutedStyle.Render(" "+t+" "))
+		}
+	}
+	b.WriteString(strings.Join(types, " ") + "\n\n")
+	b.WriteString(m.input.View() + "\n\n")
+
+	// Candidates from the current list
+	maxVisible := 8
+	for i, c := range m.filtered {
+		if i >= maxVisible {
+			b.WriteString(mutedStyle.Render("  ... "+itoa(len(m.filtered)-maxVisible)+" more") + "\n")
+			break
+		}
+		line := "#" + padRight(itoa(c.ID), 8) + padRight(c.ShortType(), 9) + truncateStr(c.Title, modalWidth-26)
+		if i == m.addCursor {
+			b.WriteString(selectedStyle.Render("▸ "+line) + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if linkTypes[m.typeIndex] == "Parent" && m.item.ParentID > 0 {
+		warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+		b.WriteString(warnStyle.Render("Replaces the current parent #"+itoa(m.item.ParentID)) + "\n")
+	}
+	if target := m.target(); target > 0 {
+		b.WriteString("Enter: link as " + linkTypes[m.typeIndex] + " of #" + itoa(target) + "\n")
+	}
+	b.WriteString(mutedStyle.Render("Tab: link type  ↑/↓: pick  Esc: back"))
+
+	return b.String()
+}
+
+// SetItem sets the work item whose links are managed. candidates are the items
+// offered for new links; the item itself is left out.
+func (m *LinkModal) SetItem(item *models.WorkItem, candidates []models.WorkItem) {
+	m.item = item
+	m.candidates = m.candidates[:0]
+	for _, c := range candidates {
+		if c.ID != item.ID {
+			m.candidates = append(m.candidates, c)
+		}
+	}
+	if m.cursor >= len(item.RelatedLinks) {
+		m.cursor = 0
+	}
+	m.adding = false
+	m.confirmRemove = false
+	m.errMsg = ""
+	m.input.Blur()
+}
+
+// SetError shows an error in the modal
+func (m *LinkModal) SetError(err string) {
+	m.errMsg = err
+}
+
+// ItemID returns the ID of the work item whose links are managed, or 0 if none
+func (m *LinkModal) ItemID() int {
+	if m.item == nil {
+		return 0
+	}
+	return m.item.ID
+}
+
+// SetVisible sets the visibility
+func (m *LinkModal) SetVisible(visible bool) {
+	m.visible = visible
+	if !visible {
+		m.adding = false
+		m.input.Blur()
+	}
+}
+
+// IsVisible returns whether the modal is visible
+func (m *LinkModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal container size
+func (m *LinkModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// LinkAddRequestMsg is sent when the user adds a link
+type LinkAddRequestMsg struct {
+	ItemID   int
+	LinkType string // e.g. "Parent", "Child", "Related"
+	TargetID int
+}
+
+// LinkRemoveRequestMsg is sent when the user removes a link
+type LinkRemoveRequestMsg struct {
+	ItemID   int
+	LinkType string
+	TargetID int
+}
@@ -0,0 +1,436 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+const (
+	planningBacklog = iota
+	planningSprint
+)
+
+// PlanningView is the fullscreen sprint planning mode: the backlog on the left,
+// a sprint on the right, and items moved between them
+type PlanningView struct {
+	iterations []models.Iteration // Sprints that can be planned
+	rootPath   string             // Iteration path of the backlog
+	items      []models.WorkItem  // Backlog and sprint items, in backlog order
+	target     int                // Index of the sprint shown on the right
+	focus      int                // planningBacklog or planningSprint
+	cursor     [2]int
+	offset     [2]int
+	pending    map[int]string // Iteration path of items being moved, by ID
+	loading    bool
+	notice     string
+	errMsg     string
+	styles     theme.Styles
+	keys       theme.KeyMap
+	width      int
+	height     int
+}
+
+// NewPlanningView creates a new sprint planning view
+func NewPlanningView(styles theme.Styles, keys theme.KeyMap) PlanningView {
+	return PlanningView{
+		pending: make(map[int]string),
+		styles:  styles,
+		keys:    keys,
+	}
+}
+
+// Init initializes the planning view
+func (p PlanningView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the planning view
+func (p *PlanningView) Update(msg tea.Msg) (*PlanningView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, p.keys.Back):
+		return p, func() tea.Msg { return ClosePlanningMsg{} }
+	case key.Matches(keyMsg, p.keys.Quit) && keyMsg.String() == "q":
+		return p, func() tea.Msg { return ClosePlanningMsg{} }
+	case key.Matches(keyMsg, p.keys.Refresh):
+		return p, func() tea.Msg { return ReloadPlanningMsg{} }
+	case key.Matches(keyMsg, p.keys.Up):
+		p.moveCursor(p.cursor[p.focus] - 1)
+	case key.Matches(keyMsg, p.keys.Down):
+		p.moveCursor(p.cursor[p.focus] + 1)
+	case key.Matches(keyMsg, p.keys.Top):
+		p.moveCursor(0)
+	case key.Matches(keyMsg, p.keys.Bottom):
+		p.moveCursor(len(p.paneItems(p.focus)) - 1)
+	case key.Matches(keyMsg, p.keys.NextPanel), key.Matches(keyMsg, p.keys.PrevPanel),
+		key.Matches(keyMsg, p.keys.Left), key.Matches(keyMsg, p.keys.Right):
+		p.focus = 1 - p.focus
+		p.moveCursor(p.cursor[p.focus])
+	case keyMsg.String() == "]":
+		p.selectTarget(p.target + 1)
+	case keyMsg.String() == "[":
+		p.selectTarget(p.target - 1)
+	case key.Matches(keyMsg, p.keys.Select):
+		return p, p.moveSelected()
+	}
+
+	return p, nil
+}
+
+// moveSelected moves the selected item to the other pane and requests the change
+func (p *PlanningView) moveSelected() tea.Cmd {
+	sprint := p.targetSprint()
+	items := p.paneItems(p.focus)
+	if sprint == nil || len(items) == 0 {
+		return nil
+	}
+	item := items[p.cursor[p.focus]]
+	if _, busy := p.pending[item.ID]; busy {
+		return nil
+	}
+
+	path, name := sprint.Path, sprint.Name
+	if p.focus == planningSprint {
+		path, name = p.rootPath, "Backlog"
+	}
+
+	// Move right away so totals update; reverted if the update fails
+	req := MoveToSprintRequestMsg{Item: item, IterationPath: path, IterationName: name}
+	p.pending[item.ID] = item.IterationPath
+	p.setIterationPath(item.ID, path)
+	p.notice, p.errMsg = "", ""
+	p.moveCursor(p.cursor[p.focus])
+	return func() tea.Msg { return req }
+}
+
+// setIterationPath changes the iteration of a loaded item
+func (p *PlanningView) setIterationPath(id int, path string) {
+	for i := range p.items {
+		if p.items[i].ID == id {
+			p.items[i].IterationPath = path
+		}
+	}
+}
+
+// selectTarget changes the sprint shown on the right
+func (p *PlanningView) selectTarget(index int) {
+	if index < 0 || index >= len(p.iterations) {
+		return
+	}
+	p.target = index
+	p.cursor[planningSprint] = 0
+	p.offset[planningSprint] = 0
+}
+
+// moveCursor moves the cursor of the focused pane, keeping it within the list
+func (p *PlanningView) moveCursor(index int) {
+	n := len(p.paneItems(p.focus))
+	if index >= n {
+		index = n - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	p.cursor[p.focus] = index
+
+	visible := p.listHeight()
+	if index < p.offset[p.focus] {
+		p.offset[p.focus] = index
+	} else if index >= p.offset[p.focus]+visible {
+		p.offset[p.focus] = index - visible + 1
+	}
+}
+
+// listHeight is the number of items that fit in a pane
+func (p *PlanningView) listHeight() int {
+	// Title bar, sprint totals, pane borders and headers, status bar
+	height := p.height - 7
+	if height < 1 {
+		height = 1
+	}
+	return height
+}
+
+// targetSprint returns the sprint shown on the right, or nil if there are none
+func (p *PlanningView) targetSprint() *models.Iteration {
+	if p.target >= len(p.iterations) {
+		return nil
+	}
+	return &p.iterations[p.target]
+}
+
+// paneItems returns the items of the backlog or the target sprint
+func (p *PlanningView) paneItems(pane int) []models.WorkItem {
+	path := p.rootPath
+	if pane == planningSprint {
+		sprint := p.targetSprint()
+		if sprint == nil {
+			return nil
+		}
+		path = sprint.Path
+	}
+	return p.itemsIn(path)
+}
+
+// itemsIn returns the items in an iteration
+func (p *PlanningView) itemsIn(path string) []models.WorkItem {
+	var items []models.WorkItem
+	for _, item := range p.items {
+		if item.IterationPath == path {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// totalPoints sums the points of items
+func totalPoints(items []models.WorkItem) float64 {
+	var total float64
+	for _, item := range items {
+		total += item.Points()
+	}
+	return total
+}
+
+// View renders the planning view
+func (p *PlanningView) View() string {
+	titleBar := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Width(p.width).
+		Render("Sprint Planning")
+
+	bodyHeight := p.height - 3
+	if bodyHeight < 1 {
+		bodyHeight = 1
+	}
+
+	var message string
+	switch {
+	case p.loading:
+		message = p.styles.Subtitle.Render("Loading backlog...")
+	case len(p.iterations) == 0:
+		message = p.styles.Subtitle.Render("No current or upcoming sprints - add iterations to the team first")
+	}
+	if message != "" {
+		body := p.styles.PanelActive.Width(p.width - 2).Height(bodyHeight - 2).Render(message)
+		return lipgloss.JoinVertical(lipgloss.Left, titleBar, body, p.renderStatusBar())
+	}
+
+	paneWidth := (p.width - 4) / 2
+	if paneWidth < 20 {
+		paneWidth = 20
+	}
+
+	sprint := p.targetSprint()
+	backlogItems := p.paneItems(planningBacklog)
+	sprintItems := p.paneItems(planningSprint)
+
+	backlogTitle := fmt.Sprintf("Backlog · %d items · %s pts", len(backlogItems), models.FormatPoints(totalPoints(backlogItems)))
+	sprintTitle := fmt.Sprintf("%s · %d items · %s pts", sprint.Name, len(sprintItems), models.FormatPoints(totalPoints(sprintItems)))
+	if dates := sprint.DateRange(); dates != "" {
+		sprintTitle = fmt.Sprintf("%s · %s · %d items · %s pts", sprint.Name, dates, len(sprintItems), models.FormatPoints(totalPoints(sprintItems)))
+	}
+
+	paneHeight := bodyHeight - 3
+	left := p.renderPane(planningBacklog, backlogTitle, backlogItems, paneWidth, paneHeight)
+	right := p.renderPane(planningSprint, sprintTitle, sprintItems, paneWidth, paneHeight)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleBar,
+		p.renderTotals(),
+		lipgloss.JoinHorizontal(lipgloss.Top, left, right),
+		p.renderStatusBar(),
+	)
+}
+
+// renderTotals renders the running points total of every sprint
+func (p *PlanningView) renderTotals() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	targetStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+
+	parts := make([]string, 0, len(p.iterations))
+	for i, it := range p.iterations {
+		items := p.itemsIn(it.Path)
+		part := fmt.Sprintf("%s %s pts (%d)", it.DisplayName(), models.FormatPoints(totalPoints(items)), len(items))
+		if i == p.target {
+			parts = append(parts, targetStyle.Render("▸ "+part))
+		} else {
+			parts = append(parts, "  "+part)
+		}
+	}
+	line := strings.Join(parts, mutedStyle.Render(" │"))
+
+	switch {
+	case p.errMsg != "":
+		line += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(p.errMsg)
+	case p.notice != "":
+		line += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Render(p.notice)
+	}
+	return lipgloss.NewStyle().MaxWidth(p.width).Render(" " + line)
+}
+
+// renderPane renders the backlog or sprint items with a header
+func (p *PlanningView) renderPane(pane int, title string, items []models.WorkItem, width, height int) string {
+	headerStyle := lipgloss.NewStyle().Bold(true)
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+	pendingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	lines := []string{headerStyle.Render(truncateStr(title, width-2))}
+	if len(items) == 0 {
+		lines = append(lines, mutedStyle.Render("No items"))
+	}
+
+	// ID, type, points, then the title in the remaining width
+	titleWidth := width - 2 - 8 - 7 - 5
+	if titleWidth < 5 {
+		titleWidth = 5
+	}
+	for i := p.offset[pane]; i < len(items) && len(lines) < height; i++ {
+		item := items[i]
+		points := ""
+		if item.Points() > 0 {
+			points = models.FormatPoints(item.Points())
+		}
+		line := padRight(fmt.Sprintf("#%d", item.ID), 8) + padRight(truncateStr(item.ShortType(), 6), 7) +
+			padRight(points, 5) + truncateStr(item.Title, titleWidth)
+
+		_, busy := p.pending[item.ID]
+		switch {
+		case i == p.cursor[pane] && pane == p.focus:
+			line = selectedStyle.Render("▸ " + line)
+		case busy:
+			line = pendingStyle.Render("  " + line)
+		default:
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+
+	style := p.styles.PanelInactive
+	if pane == p.focus {
+		style = p.styles.PanelActive
+	}
+	return style.Width(width).Height(height).Render(strings.Join(lines, "\n"))
+}
+
+func (p *PlanningView) renderStatusBar() string {
+	help := "Esc Back  Tab/h/l Switch pane  Enter/Space Move item  [/] Sprint  Ctrl+r Reload"
+	if len(p.pending) > 0 {
+		help += fmt.Sprintf("  Saving %d...", len(p.pending))
+	}
+	return p.styles.StatusBar.
+		Width(p.width).
+		Render(help)
+}
+
+// SetIterations sets the sprints to plan and marks the view as loading. The sprint
+// shown on the right is kept on reload, and is the first upcoming one otherwise.
+func (p *PlanningView) SetIterations(iterations []models.Iteration, rootPath string) {
+	shown := ""
+	if sprint := p.targetSprint(); sprint != nil {
+		shown = sprint.Path
+	}
+
+	p.iterations = iterations
+	p.rootPath = rootPath
+	p.items = nil
+	p.pending = make(map[int]string)
+	p.target = -1
+	for i, it := range iterations {
+		if it.Path == shown {
+			p.target = i
+		}
+	}
+	if p.target < 0 {
+		p.target = 0
+		for i, it := range iterations {
+			if it.IsFuture() {
+				p.target = i
+				break
+			}
+		}
+	}
+	p.focus = planningBacklog
+	p.cursor = [2]int{}
+	p.offset = [2]int{}
+	p.loading = true
+	p.notice, p.errMsg = "", ""
+}
+
+// IterationPaths returns the paths of the backlog and the sprints, for loading items
+func (p *PlanningView) IterationPaths() []string {
+	paths := []string{p.rootPath}
+	for _, it := range p.iterations {
+		paths = append(paths, it.Path)
+	}
+	return paths
+}
+
+// SetItems sets the loaded backlog and sprint items
+func (p *PlanningView) SetItems(items []models.WorkItem) {
+	p.items = items
+	p.pending = make(map[int]string)
+	p.loading = false
+	p.errMsg = ""
+	p.moveCursor(p.cursor[p.focus])
+}
+
+// MoveDone replaces a moved item with its updated version
+func (p *PlanningView) MoveDone(item models.WorkItem, notice string) {
+	delete(p.pending, item.ID)
+	for i := range p.items {
+		if p.items[i].ID == item.ID {
+			p.items[i] = item
+		}
+	}
+	p.notice = notice
+}
+
+// MoveFailed moves an item back to where it was before a failed update
+func (p *PlanningView) MoveFailed(id int, err string) {
+	if path, ok := p.pending[id]; ok {
+		p.setIterationPath(id, path)
+		delete(p.pending, id)
+	}
+	p.notice = ""
+	p.errMsg = err
+	p.moveCursor(p.cursor[p.focus])
+}
+
+// IsPending reports whether a move of the item is being saved
+func (p *PlanningView) IsPending(id int) bool {
+	_, ok := p.pending[id]
+	return ok
+}
+
+// SetError shows an error, ending the loading state
+func (p *PlanningView) SetError(err string) {
+	p.errMsg = err
+	p.loading = false
+}
+
+// SetSize sets the size of the planning view
+func (p *PlanningView) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// ClosePlanningMsg is sent when the sprint planning view should be closed
+type ClosePlanningMsg struct{}
+
+// ReloadPlanningMsg is sent when the planning items should be loaded again
+type ReloadPlanningMsg struct{}
@@ -0,0 +1,195 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// SprintModal is a modal for moving a work item to another iteration
+type SprintModal struct {
+	visible    bool
+	item       *models.WorkItem
+	iterations []models.Iteration
+	rootPath   string // Iteration path of the backlog, i.e. the project root
+	cursor     int    // 0 is the backlog, 1.. the iterations
+	styles     theme.Styles
+	keys       theme.KeyMap
+	width      int
+	height     int
+}
+
+// NewSprintModal creates a new move to sprint modal
+func NewSprintModal(styles theme.Styles, keys theme.KeyMap) SprintModal {
+	return SprintModal{
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the modal
+func (m SprintModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m SprintModal) Update(msg tea.Msg) (SprintModal, tea.Cmd) {
+	if !m.visible || m.item == nil {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		m.visible = false
+		return m, func() tea.Msg { return ModalClosedMsg{} }
+	case key.Matches(keyMsg, m.keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, m.keys.Down):
+		if m.cursor < len(m.iterations) {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, m.keys.Top):
+		m.cursor = 0
+	case key.Matches(keyMsg, m.keys.Bottom):
+		m.cursor = len(m.iterations)
+	case key.Matches(keyMsg, m.keys.Select):
+		path, name := m.rootPath, "Backlog"
+		if m.cursor > 0 {
+			it := m.iterations[m.cursor-1]
+			path, name = it.Path, it.Name
+		}
+		if path == m.item.IterationPath {
+			m.visible = false
+			return m, func() tea.Msg { return ModalClosedMsg{} }
+		}
+		req := MoveToSprintRequestMsg{Item: *m.item, IterationPath: path, IterationName: name}
+		return m, func() tea.Msg { return req }
+	}
+
+	return m, nil
+}
+
+// View renders the modal
+func (m SprintModal) View() string {
+	if !m.visible || m.item == nil {
+		return ""
+	}
+
+	modalWidth := 64
+
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Render("Move to Sprint")
+	itemInfo := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF")).
+		Render("#" + itoa(m.item.ID) + " " + truncateStr(m.item.Title, 44))
+	b.WriteString(title + "\n")
+	b.WriteString(itemInfo + "\n\n")
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+	currentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+
+	// Keep the cursor in a window of visible rows
+	maxVisible := 12
+	start := 0
+	if m.cursor >= maxVisible {
+		start = m.cursor - maxVisible + 1
+	}
+
+	for row := start; row <= len(m.iterations) && row < start+maxVisible; row++ {
+		name, path, marker, dates := "Backlog", m.rootPath, "", ""
+		var markerStyle lipgloss.Style
+		if row > 0 {
+			it := m.iterations[row-1]
+			name, path, dates = it.Name, it.Path, it.DateRange()
+			switch {
+			case it.IsCurrent():
+				marker, markerStyle = "current", currentStyle
+			case it.IsPast():
+				marker, markerStyle = "past", mutedStyle
+			case it.IsFuture():
+				marker, markerStyle = "future", lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+			}
+		}
+
+		cursor, current := " ", " "
+		if row == m.cursor {
+			cursor = "▸"
+		}
+		if path == m.item.IterationPath {
+			current = "●"
+		}
+		line := cursor + current + " " + padRight(truncateStr(name, 24), 25) + padRight(dates, 18)
+		switch {
+		case row == m.cursor:
+			line = selectedStyle.Render(line)
+		case row > 0 && m.iterations[row-1].IsPast():
+			line = mutedStyle.Render(line)
+		}
+		b.WriteString(line + markerStyle.Render(marker) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("● current iteration of the item  Enter: move  Esc: cancel"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Background(lipgloss.Color("#1F2937"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(b.String()))
+}
+
+// SetItem sets the work item to move and puts the cursor on its iteration
+func (m *SprintModal) SetItem(item *models.WorkItem) {
+	m.item = item
+	m.cursor = 0
+	for i, it := range m.iterations {
+		if it.Path == item.IterationPath {
+			m.cursor = i + 1
+		}
+	}
+}
+
+// SetIterations sets the iterations to choose from and the backlog's iteration path
+func (m *SprintModal) SetIterations(iterations []models.Iteration, rootPath string) {
+	m.iterations = iterations
+	m.rootPath = rootPath
+}
+
+// SetVisible sets the visibility
+func (m *SprintModal) SetVisible(visible bool) {
+	m.visible = visible
+}
+
+// IsVisible returns whether the modal is visible
+func (m *SprintModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal container size
+func (m *SprintModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveToSprintRequestMsg is sent when the user moves a work item to an iteration
+type MoveToSprintRequestMsg struct {
+	Item          models.WorkItem
+	IterationPath string
+	IterationName string
+}
@@ -0,0 +1,451 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// memberLoad is the capacity of one member against their remaining work
+type memberLoad struct {
+	name      string
+	perDay    float64
+	daysOff   int
+	capacity  float64
+	remaining float64
+	planned   bool // Has capacity set for the sprint
+}
+
+// SprintView is the fullscreen sprint dashboard: team capacity against remaining
+// work, and the burndown of the sprint
+type SprintView struct {
+	iterations []models.Iteration
+	index      int // Shown iteration
+	capacity   *models.TeamCapacity
+	items      []models.WorkItem
+	burndown   []models.BurndownPoint
+	loading    bool
+	errMsg     string
+	now        time.Time
+	styles     theme.Styles
+	keys       theme.KeyMap
+	width      int
+	height     int
+}
+
+// NewSprintView creates a new sprint dashboard view
+func NewSprintView(styles theme.Styles, keys theme.KeyMap) SprintView {
+	return SprintView{
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the sprint view
+func (s SprintView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the sprint view
+func (s *SprintView) Update(msg tea.Msg) (*SprintView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return s, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, s.keys.Back):
+		return s, func() tea.Msg { return CloseSprintViewMsg{} }
+	case key.Matches(keyMsg, s.keys.Quit) && keyMsg.String() == "q":
+		return s, func() tea.Msg { return CloseSprintViewMsg{} }
+	case key.Matches(keyMsg, s.keys.Refresh):
+		return s, func() tea.Msg { return ReloadSprintViewMsg{} }
+	case keyMsg.String() == "]" && s.index < len(s.iterations)-1:
+		s.index++
+		return s, func() tea.Msg { return ReloadSprintViewMsg{} }
+	case keyMsg.String() == "[" && s.index > 0:
+		s.index--
+		return s, func() tea.Msg { return ReloadSprintViewMsg{} }
+	}
+
+	return s, nil
+}
+
+// View renders the sprint view
+func (s *SprintView) View() string {
+	titleBar := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Width(s.width).
+		Render("Sprint Dashboard")
+
+	bodyHeight := s.height - 2
+	if bodyHeight < 3 {
+		bodyHeight = 3
+	}
+
+	var message string
+	switch {
+	case len(s.iterations) == 0:
+		message = s.styles.Subtitle.Render("No sprints with dates - set iteration dates for the team first")
+	case s.loading:
+		message = s.styles.Subtitle.Render("Loading capacity and history...")
+	case s.errMsg != "":
+		message = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(s.errMsg)
+	}
+	if message != "" {
+		body := s.styles.PanelActive.Width(s.width - 2).Height(bodyHeight - 2).Render(s.renderSprintLine() + "\n\n" + message)
+		return lipgloss.JoinVertical(lipgloss.Left, titleBar, body, s.renderStatusBar())
+	}
+
+	capacity := s.renderCapacity()
+	chartHeight := bodyHeight - lipgloss.Height(capacity) - 4
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		s.renderSprintLine(),
+		"",
+		capacity,
+		"",
+		s.renderBurndown(chartHeight),
+	)
+	body = lipgloss.NewStyle().Width(s.width).Height(bodyHeight).MaxHeight(bodyHeight).Render(body)
+	return lipgloss.JoinVertical(lipgloss.Left, titleBar, body, s.renderStatusBar())
+}
+
+// renderSprintLine renders the shown sprint, its dates and progress
+func (s *SprintView) renderSprintLine() string {
+	it := s.Iteration()
+	if it == nil {
+		return ""
+	}
+
+	nameStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#06B6D4"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	line := " " + nameStyle.Render(it.DisplayName())
+	if dates := it.DateRange(); dates != "" {
+		line += mutedStyle.Render(" · " + dates)
+	}
+	if s.capacity != nil {
+		days := s.capacity.SprintDays(it)
+		elapsed := 0
+		for _, day := range days {
+			if !day.After(s.now) {
+				elapsed++
+			}
+		}
+		line += mutedStyle.Render(fmt.Sprintf(" · day %d of %d", elapsed, len(days)))
+		if n := len(s.capacity.TeamDaysOff); n > 0 {
+			line += mutedStyle.Render(fmt.Sprintf(" · %d team days off", n))
+		}
+	}
+	return line
+}
+
+// capacityDays returns the sprint days capacity counts: those left in a current
+// sprint, all of them otherwise
+func (s *SprintView) capacityDays() []time.Time {
+	it := s.Iteration()
+	days := s.capacity.SprintDays(it)
+	if !it.IsCurrent() {
+		return days
+	}
+	today := time.Date(s.now.Year(), s.now.Month(), s.now.Day(), 0, 0, 0, 0, time.UTC)
+	var left []time.Time
+	for _, day := range days {
+		if !day.Before(today) {
+			left = append(left, day)
+		}
+	}
+	return left
+}
+
+// loads returns every member's capacity and remaining work, members with
+// capacity first, then other assignees, then unassigned work
+func (s *SprintView) loads() []memberLoad {
+	days := s.capacityDays()
+
+	remaining := make(map[string]float64)
+	for _, item := range s.items {
+		remaining[item.AssignedTo] += item.RemainingWork
+	}
+
+	var loads []memberLoad
+	seen := make(map[string]bool)
+	for i := range s.capacity.Members {
+		m := &s.capacity.Members[i]
+		seen[strings.ToLower(m.Member.DisplayName)] = true
+		var rem float64
+		for name, r := range remaining {
+			if strings.EqualFold(name, m.Member.DisplayName) {
+				rem += r
+			}
+		}
+		loads = append(loads, memberLoad{
+			name:      m.Member.DisplayName,
+			perDay:    m.CapacityPerDay,
+			daysOff:   m.DaysOffIn(days),
+			capacity:  m.Hours(days),
+			remaining: rem,
+			planned:   true,
+		})
+	}
+
+	var others []string
+	for name, r := range remaining {
+		if name != "" && r > 0 && !seen[strings.ToLower(name)] {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		loads = append(loads, memberLoad{name: name, remaining: remaining[name]})
+	}
+	if r := remaining[""]; r > 0 {
+		loads = append(loads, memberLoad{name: "Unassigned", remaining: r})
+	}
+	return loads
+}
+
+// renderCapacity renders a table of capacity against remaining work per member
+func (s *SprintView) renderCapacity() string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#9CA3AF"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	overStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+
+	loads := s.loads()
+	if len(loads) == 0 {
+		return mutedStyle.Render(" No capacity set and no remaining work in this sprint")
+	}
+
+	barWidth := s.width - 2 - 22 - 9 - 10 - 10 - 11 - 2
+	if barWidth < 10 {
+		barWidth = 10
+	}
+
+	label := "CAPACITY"
+	if s.Iteration().IsCurrent() {
+		label = "LEFT"
+	}
+	lines := []string{headerStyle.Render(" " + padRight("MEMBER", 22) + padRight("PER DAY", 9) + padRight("DAYS OFF", 10) +
+		padRight(label, 10) + padRight("REMAINING", 11))}
+
+	var total memberLoad
+	total.name = "Team"
+	for _, l := range loads {
+		lines = append(lines, s.renderLoad(l, barWidth, mutedStyle, overStyle, okStyle))
+		total.perDay += l.perDay
+		total.capacity += l.capacity
+		total.remaining += l.remaining
+	}
+	total.planned = true
+	total.daysOff = -1
+	lines = append(lines, mutedStyle.Render(" "+strings.Repeat("─", 22+9+10+10+11+barWidth)))
+	lines = append(lines, lipgloss.NewStyle().Bold(true).Render(s.renderLoad(total, barWidth, mutedStyle, overStyle, okStyle)))
+	return strings.Join(lines, "\n")
+}
+
+// renderLoad renders one row of the capacity table
+func (s *SprintView) renderLoad(l memberLoad, barWidth int, mutedStyle, overStyle, okStyle lipgloss.Style) string {
+	perDay, daysOff, capacity := "-", "", "-"
+	if l.planned {
+		perDay = models.FormatPoints(l.perDay) + "h"
+		capacity = models.FormatPoints(l.capacity) + "h"
+		if l.daysOff >= 0 {
+			daysOff = fmt.Sprintf("%d", l.daysOff)
+		}
+	}
+	row := " " + padRight(truncateStr(l.name, 21), 22) + padRight(perDay, 9) + padRight(daysOff, 10) +
+		padRight(capacity, 10) + padRight(models.FormatPoints(l.remaining)+"h", 11)
+
+	if l.capacity <= 0 {
+		if l.remaining > 0 {
+			return row + overStyle.Render("no capacity")
+		}
+		return row
+	}
+	filled := int(math.Round(l.remaining / l.capacity * float64(barWidth)))
+	style := okStyle
+	if l.remaining > l.capacity {
+		filled = barWidth
+		style = overStyle
+	}
+	return row + style.Render(strings.Repeat("█", filled)) + mutedStyle.Render(strings.Repeat("░", barWidth-filled))
+}
+
+// renderBurndown renders the remaining work per sprint day as bars against the
+// ideal trend, a straight line from the first day's total down to zero
+func (s *SprintView) renderBurndown(height int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	barStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#06B6D4"))
+	idealStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+
+	title := lipgloss.NewStyle().Bold(true).Render(" Burndown")
+	days := s.capacity.SprintDays(s.Iteration())
+	if len(days) == 0 {
+		return title + "\n" + mutedStyle.Render(" The sprint has no dates")
+	}
+	if len(s.burndown) == 0 {
+		return title + "\n" + mutedStyle.Render(" The sprint hasn't started")
+	}
+
+	start := s.burndown[0].Remaining
+	maxY := start
+	for _, p := range s.burndown {
+		maxY = math.Max(maxY, p.Remaining)
+	}
+	if maxY == 0 {
+		return title + "\n" + mutedStyle.Render(" No remaining work recorded in this sprint")
+	}
+
+	last := s.burndown[len(s.burndown)-1].Remaining
+	title += mutedStyle.Render(fmt.Sprintf("  %sh remaining of %sh  ", models.FormatPoints(last), models.FormatPoints(start))) +
+		barStyle.Render("█") + mutedStyle.Render(" remaining  ") + idealStyle.Render("·") + mutedStyle.Render(" ideal")
+
+	rows := height - 3
+	if rows < 3 {
+		rows = 3
+	}
+	colWidth := (s.width - 10) / len(days)
+	if colWidth > 4 {
+		colWidth = 4
+	}
+	if colWidth < 1 {
+		colWidth = 1
+	}
+	barWidth := colWidth - 1
+	if barWidth < 1 {
+		barWidth = 1
+	}
+
+	ideal := func(i int) float64 {
+		if len(days) == 1 {
+			return 0
+		}
+		return start * (1 - float64(i)/float64(len(days)-1))
+	}
+
+	lines := []string{title}
+	for r := 0; r < rows; r++ {
+		// Each row covers a band of hours; a bar fills it when it reaches its middle
+		top := maxY * float64(rows-r) / float64(rows)
+		bottom := maxY * float64(rows-r-1) / float64(rows)
+		mid := (top + bottom) / 2
+
+		axis := "      "
+		switch r {
+		case 0:
+			axis = fmt.Sprintf("%5s ", models.FormatPoints(maxY))
+		case rows - 1:
+			axis = fmt.Sprintf("%5s ", "0")
+		}
+
+		var b strings.Builder
+		b.WriteString(mutedStyle.Render(axis + "│"))
+		for i := range days {
+			idealHere := ideal(i) <= top && (ideal(i) > bottom || (r == rows-1 && ideal(i) >= 0))
+			cell := strings.Repeat(" ", colWidth)
+			switch {
+			case i < len(s.burndown) && s.burndown[i].Remaining >= mid && s.burndown[i].Remaining > 0:
+				cell = barStyle.Render(strings.Repeat("█", barWidth)) + strings.Repeat(" ", colWidth-barWidth)
+			case idealHere:
+				cell = idealStyle.Render("·") + strings.Repeat(" ", colWidth-1)
+			}
+			b.WriteString(cell)
+		}
+		lines = append(lines, b.String())
+	}
+
+	// Day of the month under every few days
+	lines = append(lines, mutedStyle.Render("      └"+strings.Repeat("─", colWidth*len(days))))
+	every := (3 + colWidth - 1) / colWidth
+	var labels strings.Builder
+	labels.WriteString("       ")
+	for i := 0; i < len(days); i += every {
+		label := fmt.Sprintf("%d", days[i].Day())
+		labels.WriteString(padRight(label, every*colWidth))
+	}
+	lines = append(lines, mutedStyle.Render(labels.String()))
+
+	return strings.Join(lines, "\n")
+}
+
+func (s *SprintView) renderStatusBar() string {
+	return s.styles.StatusBar.
+		Width(s.width).
+		Render("Esc Back  [/] Sprint  Ctrl+r Reload")
+}
+
+// SetIterations sets the sprints to choose from and shows the one with the given
+// path, or the current sprint if there's no such sprint
+func (s *SprintView) SetIterations(iterations []models.Iteration, path string) {
+	s.iterations = nil
+	for _, it := range iterations {
+		if !it.StartDate.IsZero() && !it.FinishDate.IsZero() {
+			s.iterations = append(s.iterations, it)
+		}
+	}
+	s.index = 0
+	for i, it := range s.iterations {
+		if it.IsCurrent() {
+			s.index = i
+		}
+	}
+	for i, it := range s.iterations {
+		if it.Path == path {
+			s.index = i
+		}
+	}
+}
+
+// Iteration returns the shown sprint, or nil if there are none
+func (s *SprintView) Iteration() *models.Iteration {
+	if s.index >= len(s.iterations) {
+		return nil
+	}
+	return &s.iterations[s.index]
+}
+
+// SetLoading marks the view as loading the shown sprint
+func (s *SprintView) SetLoading() {
+	s.loading = true
+	s.errMsg = ""
+}
+
+// SetData sets the loaded capacity, items and burndown of the shown sprint
+func (s *SprintView) SetData(capacity *models.TeamCapacity, items []models.WorkItem, burndown []models.BurndownPoint) {
+	s.capacity = capacity
+	s.items = items
+	s.burndown = burndown
+	s.now = time.Now()
+	s.loading = false
+	s.errMsg = ""
+}
+
+// SetError shows an error, ending the loading state
+func (s *SprintView) SetError(err string) {
+	s.errMsg = err
+	s.loading = false
+}
+
+// SetSize sets the size of the sprint view
+func (s *SprintView) SetSize(width, height int) {
+	s.width = width
+	s.height = height
+}
+
+// CloseSprintViewMsg is sent when the sprint dashboard should be closed
+type CloseSprintViewMsg struct{}
+
+// ReloadSprintViewMsg is sent when the shown sprint should be loaded again
+type ReloadSprintViewMsg struct{}
@@ -0,0 +1,446 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// taskboardRow is the tasks of one parent work item
+type taskboardRow struct {
+	parentID int // 0 for tasks without a parent
+	title    string
+	tasks    []models.WorkItem
+}
+
+// TaskboardView is the fullscreen sprint taskboard: a row per parent story, a
+// column per state category and the listed tasks as cards
+type TaskboardView struct {
+	items        []models.WorkItem
+	statesByType map[string][]models.WorkItemStateInfo
+	columns      []string // State categories
+	col          int      // Selected column
+	card         int      // Selected card within the column
+	pending      map[int]models.WorkItem
+	notice       string
+	errMsg       string
+	styles       theme.Styles
+	keys         theme.KeyMap
+	width        int
+	height       int
+}
+
+// NewTaskboardView creates a new taskboard view
+func NewTaskboardView(styles theme.Styles, keys theme.KeyMap) TaskboardView {
+	return TaskboardView{
+		pending: make(map[int]models.WorkItem),
+		styles:  styles,
+		keys:    keys,
+	}
+}
+
+// Init initializes the taskboard view
+func (t TaskboardView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the taskboard view
+func (t *TaskboardView) Update(msg tea.Msg) (*TaskboardView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return t, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, t.keys.Back):
+		return t, func() tea.Msg { return CloseTaskboardMsg{} }
+	case key.Matches(keyMsg, t.keys.Quit) && keyMsg.String() == "q":
+		return t, func() tea.Msg { return CloseTaskboardMsg{} }
+	case key.Matches(keyMsg, t.keys.Refresh):
+		return t, func() tea.Msg { return ReloadTaskboardMsg{} }
+	case key.Matches(keyMsg, t.keys.Left):
+		t.selectColumn(t.col - 1)
+	case key.Matches(keyMsg, t.keys.Right):
+		t.selectColumn(t.col + 1)
+	case key.Matches(keyMsg, t.keys.Up):
+		t.selectCard(t.card - 1)
+	case key.Matches(keyMsg, t.keys.Down):
+		t.selectCard(t.card + 1)
+	case key.Matches(keyMsg, t.keys.Top):
+		t.selectCard(0)
+	case key.Matches(keyMsg, t.keys.Bottom):
+		t.selectCard(len(t.cards(t.col)) - 1)
+	case keyMsg.String() == ">":
+		return t, t.moveCard(1)
+	case keyMsg.String() == "<":
+		return t, t.moveCard(-1)
+	case key.Matches(keyMsg, t.keys.Open):
+		if item := t.selectedCard(); item != nil {
+			open := *item
+			return t, func() tea.Msg { return OpenWorkItemMsg{Item: open} }
+		}
+	}
+
+	return t, nil
+}
+
+// rows groups the listed tasks by parent, in the order parents first appear
+func (t *TaskboardView) rows() []taskboardRow {
+	titles := make(map[int]string)
+	for _, item := range t.items {
+		titles[item.ID] = fmt.Sprintf("#%d %s %s", item.ID, item.ShortType(), item.Title)
+	}
+
+	var rows []taskboardRow
+	index := make(map[int]int)
+	for _, item := range t.items {
+		if item.Type != models.WorkItemTypeTask || t.columnOf(&item) < 0 {
+			continue
+		}
+		i, ok := index[item.ParentID]
+		if !ok {
+			title, listed := titles[item.ParentID]
+			switch {
+			case item.ParentID == 0:
+				title = "Tasks without a parent"
+			case !listed:
+				title = fmt.Sprintf("#%d %s", item.ParentID, item.ParentTitle)
+			}
+			i = len(rows)
+			index[item.ParentID] = i
+			rows = append(rows, taskboardRow{parentID: item.ParentID, title: title})
+		}
+		rows[i].tasks = append(rows[i].tasks, item)
+	}
+	return rows
+}
+
+// columnOf returns the column of a task's state category, or -1 if it isn't shown
+func (t *TaskboardView) columnOf(item *models.WorkItem) int {
+	category := item.StateCategory(t.statesByType)
+	for i, c := range t.columns {
+		if c == category {
+			return i
+		}
+	}
+	return -1
+}
+
+// cards returns the tasks in a column, in row order
+func (t *TaskboardView) cards(col int) []models.WorkItem {
+	var cards []models.WorkItem
+	for _, row := range t.rows() {
+		for _, task := range row.tasks {
+			if t.columnOf(&task) == col {
+				cards = append(cards, task)
+			}
+		}
+	}
+	return cards
+}
+
+// selectedCard returns the selected task, or nil if the column is empty
+func (t *TaskboardView) selectedCard() *models.WorkItem {
+	cards := t.cards(t.col)
+	if t.card >= len(cards) {
+		return nil
+	}
+	return &cards[t.card]
+}
+
+// selectColumn moves the column cursor
+func (t *TaskboardView) selectColumn(index int) {
+	if index < 0 || index >= len(t.columns) {
+		return
+	}
+	t.col = index
+	t.selectCard(t.card)
+}
+
+// selectCard moves the card cursor, keeping it within the column
+func (t *TaskboardView) selectCard(index int) {
+	n := len(t.cards(t.col))
+	if index >= n {
+		index = n - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	t.card = index
+}
+
+// moveCard moves the selected task to the adjacent column in direction dir, changing
+// its state to the first state of that category
+func (t *TaskboardView) moveCard(dir int) tea.Cmd {
+	item := t.selectedCard()
+	col := t.col + dir
+	if item == nil || col < 0 || col >= len(t.columns) {
+		return nil
+	}
+	if _, busy := t.pending[item.ID]; busy {
+		return nil
+	}
+
+	states := models.StatesInCategory(t.statesByType, item.Type, t.columns[col])
+	if len(states) == 0 {
+		t.errMsg = fmt.Sprintf("No %s state known for %s", models.CategoryLabel(t.columns[col]), item.Type)
+		return nil
+	}
+
+	// Move right away; moved back if the update fails
+	req := TaskMoveRequestMsg{Item: *item, State: states[0]}
+	t.pending[item.ID] = *item
+	t.notice, t.errMsg = "", ""
+	for i := range t.items {
+		if t.items[i].ID == item.ID {
+			t.items[i].State = models.WorkItemState(states[0])
+		}
+	}
+	t.col = col
+	for i, card := range t.cards(col) {
+		if card.ID == req.Item.ID {
+			t.card = i
+		}
+	}
+	return func() tea.Msg { return req }
+}
+
+// View renders the taskboard view
+func (t *TaskboardView) View() string {
+	titleBar := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Width(t.width).
+		Render("Taskboard")
+
+	bodyHeight := t.height - 2
+	if bodyHeight < 3 {
+		bodyHeight = 3
+	}
+
+	rows := t.rows()
+	if len(rows) == 0 {
+		message := t.styles.Subtitle.Render("No tasks listed - select a sprint in the filters")
+		body := t.styles.PanelActive.Width(t.width - 2).Height(bodyHeight - 3).Render(message)
+		return lipgloss.JoinVertical(lipgloss.Left, titleBar, body, t.renderStatusBar())
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, titleBar, t.renderBoard(rows, bodyHeight), t.renderStatusBar())
+}
+
+// renderBoard renders the column headers and a block of cards per row, scrolled to
+// keep the selected card in view
+func (t *TaskboardView) renderBoard(rows []taskboardRow, height int) string {
+	n := len(t.columns)
+	colWidth := (t.width+1)/n - 1
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	sep := mutedStyle.Render("│")
+
+	var headers []string
+	for i, category := range t.columns {
+		text := fmt.Sprintf("%s %d", models.CategoryLabel(category), len(t.cards(i)))
+		style := lipgloss.NewStyle().Bold(true)
+		if i == t.col {
+			style = style.Underline(true)
+		}
+		headers = append(headers, style.Render(fitCell(text, colWidth)))
+	}
+
+	selected := t.selectedCard()
+	rowStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#06B6D4"))
+
+	var lines []string
+	selectedLine := 0
+	for _, row := range rows {
+		var remaining float64
+		for _, task := range row.tasks {
+			remaining += task.RemainingWork
+		}
+		count := fmt.Sprintf("%d tasks", len(row.tasks))
+		if len(row.tasks) == 1 {
+			count = "1 task"
+		}
+		summary := fmt.Sprintf(" · %s · %sh remaining", count, models.FormatPoints(remaining))
+		title := runewidth.Truncate(row.title, t.width-runewidth.StringWidth(summary)-1, "…")
+		lines = append(lines, rowStyle.Render(title)+mutedStyle.Render(summary))
+
+		byColumn := make([][]models.WorkItem, n)
+		height := 0
+		for _, task := range row.tasks {
+			c := t.columnOf(&task)
+			byColumn[c] = append(byColumn[c], task)
+			if len(byColumn[c]) > height {
+				height = len(byColumn[c])
+			}
+		}
+		for i := 0; i < height; i++ {
+			var top, bottom []string
+			for c := 0; c < n; c++ {
+				if i >= len(byColumn[c]) {
+					top = append(top, strings.Repeat(" ", colWidth))
+					bottom = append(bottom, strings.Repeat(" ", colWidth))
+					continue
+				}
+				task := byColumn[c][i]
+				isSelected := selected != nil && task.ID == selected.ID
+				if isSelected {
+					selectedLine = len(lines)
+				}
+				tp, bt := t.renderCard(&task, isSelected, colWidth)
+				top = append(top, tp)
+				bottom = append(bottom, bt)
+			}
+			lines = append(lines, strings.Join(top, sep), strings.Join(bottom, sep))
+		}
+	}
+
+	var status string
+	switch {
+	case t.errMsg != "":
+		status = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(t.errMsg)
+	case t.notice != "":
+		status = lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Render(t.notice)
+	}
+
+	cardHeight := height - 4
+	if cardHeight < 2 {
+		cardHeight = 2
+	}
+	start := 0
+	if selectedLine+2 > cardHeight {
+		start = selectedLine + 2 - cardHeight
+	}
+	stop := start + cardHeight
+	if stop > len(lines) {
+		stop = len(lines)
+	}
+
+	out := []string{strings.Join(headers, sep), mutedStyle.Render(strings.Repeat("─", t.width)), status}
+	out = append(out, lines[start:stop]...)
+	for len(out) < height-1 {
+		out = append(out, "")
+	}
+	return strings.Join(out, "\n")
+}
+
+// renderCard renders a task as two lines of the column width
+func (t *TaskboardView) renderCard(task *models.WorkItem, selected bool, width int) (string, string) {
+	prefix := "  "
+	if selected {
+		prefix = "▸ "
+	}
+	top := fitCell(fmt.Sprintf("%s#%d %s", prefix, task.ID, task.Title), width)
+
+	assignee := task.AssignedTo
+	if assignee == "" {
+		assignee = "Unassigned"
+	}
+	details := []string{assignee}
+	if task.RemainingWork > 0 {
+		details = append(details, models.FormatPoints(task.RemainingWork)+"h")
+	}
+	bottom := fitCell("  "+strings.Join(details, " · "), width)
+
+	_, busy := t.pending[task.ID]
+	switch {
+	case selected:
+		style := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+		return style.Render(top), style.Render(bottom)
+	case busy:
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+		return style.Render(top), style.Render(bottom)
+	}
+	return top, lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(bottom)
+}
+
+func (t *TaskboardView) renderStatusBar() string {
+	help := "Esc Back  h/j/k/l Select  </> Move task  Enter Open  Ctrl+r Reload"
+	if len(t.pending) > 0 {
+		help += fmt.Sprintf("  Saving %d...", len(t.pending))
+	}
+	return t.styles.StatusBar.
+		Width(t.width).
+		Render(help)
+}
+
+// SetItems sets the listed work items; tasks among them become cards
+func (t *TaskboardView) SetItems(items []models.WorkItem) {
+	t.items = make([]models.WorkItem, len(items))
+	copy(t.items, items)
+	t.pending = make(map[int]models.WorkItem)
+	t.selectCard(t.card)
+}
+
+// SetStates sets the state metadata used to place tasks in columns
+func (t *TaskboardView) SetStates(statesByType map[string][]models.WorkItemStateInfo) {
+	t.statesByType = statesByType
+	t.columns = nil
+	for _, category := range models.TaskboardCategories {
+		// Without metadata show the categories every default process has
+		if len(statesByType[string(models.WorkItemTypeTask)]) == 0 {
+			if category != "Resolved" {
+				t.columns = append(t.columns, category)
+			}
+			continue
+		}
+		if len(models.StatesInCategory(statesByType, models.WorkItemTypeTask, category)) > 0 {
+			t.columns = append(t.columns, category)
+		}
+	}
+	if t.col >= len(t.columns) {
+		t.col = 0
+	}
+}
+
+// MoveDone replaces a moved task with its updated version
+func (t *TaskboardView) MoveDone(item models.WorkItem, notice string) {
+	delete(t.pending, item.ID)
+	for i := range t.items {
+		if t.items[i].ID == item.ID {
+			t.items[i] = item
+		}
+	}
+	t.notice = notice
+}
+
+// MoveFailed puts a task back where it was before a failed update
+func (t *TaskboardView) MoveFailed(id int, err string) {
+	if orig, ok := t.pending[id]; ok {
+		for i := range t.items {
+			if t.items[i].ID == id {
+				t.items[i] = orig
+			}
+		}
+		delete(t.pending, id)
+	}
+	t.notice = ""
+	t.errMsg = err
+	t.selectCard(t.card)
+}
+
+// SetSize sets the size of the taskboard view
+func (t *TaskboardView) SetSize(width, height int) {
+	t.width = width
+	t.height = height
+}
+
+// TaskMoveRequestMsg is sent when a task is moved to another column
+type TaskMoveRequestMsg struct {
+	Item  models.WorkItem // The task before the move
+	State string
+}
+
+// CloseTaskboardMsg is sent when the taskboard view should be closed
+type CloseTaskboardMsg struct{}
+
+// ReloadTaskboardMsg is sent when the taskboard items should be loaded again
+type ReloadTaskboardMsg struct{}
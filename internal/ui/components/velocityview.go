@@ -0,0 +1,237 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+const (
+	// MaxVelocitySprints is the number of past sprints loaded for the velocity view
+	MaxVelocitySprints = 12
+	// defaultVelocitySprints is the number of past sprints shown at first
+	defaultVelocitySprints = 6
+	minVelocitySprints     = 3
+)
+
+// VelocityView is the fullscreen velocity chart of the last past sprints
+type VelocityView struct {
+	velocity []models.SprintVelocity // All loaded sprints, oldest first
+	shown    int                     // Number of most recent sprints shown
+	loading  bool
+	errMsg   string
+	styles   theme.Styles
+	keys     theme.KeyMap
+	width    int
+	height   int
+}
+
+// NewVelocityView creates a new velocity view
+func NewVelocityView(styles theme.Styles, keys theme.KeyMap) VelocityView {
+	return VelocityView{
+		shown:  defaultVelocitySprints,
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the velocity view
+func (v VelocityView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the velocity view
+func (v *VelocityView) Update(msg tea.Msg) (*VelocityView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return v, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, v.keys.Back):
+		return v, func() tea.Msg { return CloseVelocityMsg{} }
+	case key.Matches(keyMsg, v.keys.Quit) && keyMsg.String() == "q":
+		return v, func() tea.Msg { return CloseVelocityMsg{} }
+	case key.Matches(keyMsg, v.keys.Refresh):
+		return v, func() tea.Msg { return ReloadVelocityMsg{} }
+	case keyMsg.String() == "+" || keyMsg.String() == "=":
+		if v.shown < MaxVelocitySprints {
+			v.shown++
+		}
+	case keyMsg.String() == "-":
+		if v.shown > minVelocitySprints {
+			v.shown--
+		}
+	}
+
+	return v, nil
+}
+
+// sprints returns the shown sprints, oldest first
+func (v *VelocityView) sprints() []models.SprintVelocity {
+	if len(v.velocity) <= v.shown {
+		return v.velocity
+	}
+	return v.velocity[len(v.velocity)-v.shown:]
+}
+
+// View renders the velocity view
+func (v *VelocityView) View() string {
+	titleBar := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Width(v.width).
+		Render("Velocity")
+
+	bodyHeight := v.height - 2
+	if bodyHeight < 3 {
+		bodyHeight = 3
+	}
+
+	var message string
+	switch {
+	case v.loading:
+		message = v.styles.Subtitle.Render("Loading past sprints...")
+	case v.errMsg != "":
+		message = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(v.errMsg)
+	case len(v.velocity) == 0:
+		message = v.styles.Subtitle.Render("No past sprints")
+	}
+	if message != "" {
+		body := v.styles.PanelActive.Width(v.width - 2).Height(bodyHeight - 2).Render(message)
+		return lipgloss.JoinVertical(lipgloss.Left, titleBar, body, v.renderStatusBar())
+	}
+
+	body := lipgloss.JoinVertical(lipgloss.Left, v.renderSummary(), "", v.renderChart())
+	body = lipgloss.NewStyle().Width(v.width).Height(bodyHeight).MaxHeight(bodyHeight).Render(body)
+	return lipgloss.JoinVertical(lipgloss.Left, titleBar, body, v.renderStatusBar())
+}
+
+// renderSummary renders the average and trend of the shown sprints
+func (v *VelocityView) renderSummary() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	valueStyle := lipgloss.NewStyle().Bold(true)
+
+	sprints := v.sprints()
+	avg := models.AverageVelocity(sprints)
+	trend := models.VelocityTrend(sprints)
+
+	var trendText string
+	switch {
+	case trend > 0.05:
+		trendText = lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).
+			Render(fmt.Sprintf("↑ +%s pts/sprint", models.FormatPoints(math.Round(trend*10)/10)))
+	case trend < -0.05:
+		trendText = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).
+			Render(fmt.Sprintf("↓ -%s pts/sprint", models.FormatPoints(math.Round(-trend*10)/10)))
+	default:
+		trendText = mutedStyle.Render("→ steady")
+	}
+
+	return " " + mutedStyle.Render(fmt.Sprintf("Last %d sprints · average ", len(sprints))) +
+		valueStyle.Render(models.FormatPoints(math.Round(avg*10)/10)+" pts") +
+		mutedStyle.Render(" · trend ") + trendText
+}
+
+// renderChart renders a bar per sprint: completed points, then the points left
+// incomplete, with the average marked
+func (v *VelocityView) renderChart() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#06B6D4"))
+	doneStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+	avgStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+
+	sprints := v.sprints()
+	var maxPoints float64
+	for _, s := range sprints {
+		maxPoints = math.Max(maxPoints, s.Completed+s.Incomplete)
+	}
+
+	barWidth := v.width - 2 - 20 - 17 - 28
+	if barWidth < 10 {
+		barWidth = 10
+	}
+	scale := func(points float64) int {
+		if maxPoints == 0 {
+			return 0
+		}
+		return int(math.Round(points / maxPoints * float64(barWidth)))
+	}
+	avgPos := scale(models.AverageVelocity(sprints))
+
+	var lines []string
+	for _, s := range sprints {
+		done := scale(s.Completed)
+		left := scale(s.Completed+s.Incomplete) - done
+
+		var bar strings.Builder
+		bar.WriteString(doneStyle.Render(strings.Repeat("█", done)))
+		for i := done; i < barWidth; i++ {
+			switch {
+			case i == avgPos && avgPos > 0:
+				bar.WriteString(avgStyle.Render("│"))
+			case i < done+left:
+				bar.WriteString(mutedStyle.Render("░"))
+			default:
+				bar.WriteString(" ")
+			}
+		}
+
+		label := fmt.Sprintf(" %s pts · %d items", models.FormatPoints(s.Completed), s.Items)
+		if s.Incomplete > 0 {
+			label += mutedStyle.Render(fmt.Sprintf(" (%s left)", models.FormatPoints(s.Incomplete)))
+		}
+		lines = append(lines, " "+nameStyle.Render(padRight(truncateStr(s.Iteration.Name, 19), 20))+
+			mutedStyle.Render(padRight(s.Iteration.DateRange(), 17))+bar.String()+label)
+	}
+
+	lines = append(lines, "",
+		" "+doneStyle.Render("█")+mutedStyle.Render(" completed  ")+mutedStyle.Render("░ incomplete  ")+
+			avgStyle.Render("│")+mutedStyle.Render(" average"))
+	return strings.Join(lines, "\n")
+}
+
+func (v *VelocityView) renderStatusBar() string {
+	return v.styles.StatusBar.
+		Width(v.width).
+		Render("Esc Back  +/- Sprints shown  Ctrl+r Reload")
+}
+
+// SetLoading marks the view as loading
+func (v *VelocityView) SetLoading() {
+	v.loading = true
+	v.errMsg = ""
+}
+
+// SetVelocity sets the velocity of the loaded sprints, oldest first
+func (v *VelocityView) SetVelocity(velocity []models.SprintVelocity) {
+	v.velocity = velocity
+	v.loading = false
+	v.errMsg = ""
+}
+
+// SetError shows an error, ending the loading state
+func (v *VelocityView) SetError(err string) {
+	v.errMsg = err
+	v.loading = false
+}
+
+// SetSize sets the size of the velocity view
+func (v *VelocityView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// CloseVelocityMsg is sent when the velocity view should be closed
+type CloseVelocityMsg struct{}
+
+// ReloadVelocityMsg is sent when the velocity should be loaded again
+type ReloadVelocityMsg struct{}
@@ -0,0 +1,254 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// wiqlTemplate pre-fills the editor when there is no query history yet
+const wiqlTemplate = `SELECT [System.Id]
+FROM WorkItems
+WHERE [System.TeamProject] = @project
+  AND [System.WorkItemType] = 'Bug'
+  AND [Microsoft.VSTS.Common.Priority] = 1
+  AND [System.ChangedDate] >= @StartOfWeek
+ORDER BY [System.ChangedDate] DESC`
+
+// wiqlHistoryShown is the number of recent queries listed below the editor
+const wiqlHistoryShown = 5
+
+// WIQLEditor is a modal for writing a raw WIQL query and picking recent ones
+type WIQLEditor struct {
+	visible    bool
+	textarea   textarea.Model
+	history    []string // Most recent first
+	historyIdx int      // Index into history of the shown query, -1 for the draft
+	draft      string   // Text typed before browsing history
+	running    bool
+	active     bool // The list shows a query, which ctrl+x leaves
+	errMsg     string
+	styles     theme.Styles
+	keys       theme.KeyMap
+	width      int
+	height     int
+}
+
+// NewWIQLEditor creates a new WIQL editor modal
+func NewWIQLEditor(styles theme.Styles, keys theme.KeyMap) WIQLEditor {
+	ta := textarea.New()
+	ta.Placeholder = "SELECT [System.Id] FROM WorkItems WHERE ..."
+	ta.ShowLineNumbers = true
+	ta.CharLimit = 0
+	ta.SetWidth(84)
+	ta.SetHeight(10)
+
+	return WIQLEditor{
+		textarea:   ta,
+		historyIdx: -1,
+		styles:     styles,
+		keys:       keys,
+	}
+}
+
+// Init initializes the modal
+func (m WIQLEditor) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m WIQLEditor) Update(msg tea.Msg) (WIQLEditor, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if m.running && msg.String() != "esc" {
+			return m, nil
+		}
+		switch msg.String() {
+		case "esc":
+			m.visible = false
+			m.running = false
+			m.textarea.Blur()
+			return m, func() tea.Msg { return ModalClosedMsg{} }
+		case "ctrl+s":
+			query := strings.TrimSpace(m.textarea.Value())
+			if query == "" {
+				m.errMsg = "Query cannot be empty"
+				return m, nil
+			}
+			m.errMsg = ""
+			m.running = true
+			return m, func() tea.Msg { return WIQLRunMsg{Query: query} }
+		case "ctrl+e":
+			text := m.textarea.Value()
+			return m, func() tea.Msg { return EditWIQLInEditorMsg{Text: text} }
+		case "ctrl+x":
+			if !m.active {
+				return m, nil
+			}
+			m.visible = false
+			m.textarea.Blur()
+			return m, func() tea.Msg { return WIQLClearMsg{} }
+		case "ctrl+p":
+			m.browseHistory(1)
+			return m, nil
+		case "ctrl+n":
+			m.browseHistory(-1)
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return m, cmd
+}
+
+// browseHistory shows the next older (delta 1) or newer (delta -1) query from
+// history, keeping the draft to come back to
+func (m *WIQLEditor) browseHistory(delta int) {
+	idx := m.historyIdx + delta
+	if idx < -1 || idx >= len(m.history) {
+		return
+	}
+	if m.historyIdx == -1 {
+		m.draft = m.textarea.Value()
+	}
+	m.historyIdx = idx
+	if idx == -1 {
+		m.textarea.SetValue(m.draft)
+	} else {
+		m.textarea.SetValue(m.history[idx])
+	}
+	m.errMsg = ""
+}
+
+// View renders the modal
+func (m WIQLEditor) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("WIQL query") + "\n\n")
+	b.WriteString(m.textarea.View() + "\n")
+
+	if m.errMsg != "" {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Width(84)
+		b.WriteString("\n" + errStyle.Render(m.errMsg) + "\n")
+	} else if m.running {
+		runStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+		b.WriteString("\n" + runStyle.Render("Running query...") + "\n")
+	}
+
+	if len(m.history) > 0 {
+		b.WriteString("\n" + lipgloss.NewStyle().Bold(true).Render("Recent") + "\n")
+		dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#A78BFA")).Bold(true)
+		for i, q := range m.history {
+			if i >= wiqlHistoryShown && i != m.historyIdx {
+				continue
+			}
+			line := truncate(strings.Join(strings.Fields(q), " "), 80)
+			if i == m.historyIdx {
+				b.WriteString(selectedStyle.Render("▸ "+line) + "\n")
+			} else {
+				b.WriteString(dimStyle.Render("  "+line) + "\n")
+			}
+		}
+	}
+
+	b.WriteString("\n")
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	help := "Ctrl+s: run  Ctrl+p/n: older/newer query  Ctrl+e: open in $EDITOR  Esc: cancel"
+	if m.active {
+		help += "\nCtrl+x: back to filters"
+	}
+	b.WriteString(helpStyle.Render(help))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(90).
+		Background(lipgloss.Color("#1F2937"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(b.String()))
+}
+
+// Open shows the editor with the query the list shows, or the most recent query
+// from history if it shows none
+func (m *WIQLEditor) Open(query string, history []string) tea.Cmd {
+	m.active = query != ""
+	m.history = history
+	m.historyIdx = -1
+	m.draft = ""
+	m.errMsg = ""
+	m.running = false
+	switch {
+	case query != "":
+	case len(history) > 0:
+		query = history[0]
+		m.historyIdx = 0
+	default:
+		query = wiqlTemplate
+	}
+	m.textarea.SetValue(query)
+	m.visible = true
+	return m.textarea.Focus()
+}
+
+// SetText replaces the query, e.g. after editing it in $EDITOR
+func (m *WIQLEditor) SetText(text string) {
+	m.textarea.SetValue(strings.TrimRight(text, "\n"))
+	m.historyIdx = -1
+}
+
+// SetError shows an error in the editor, e.g. a syntax error from the server
+func (m *WIQLEditor) SetError(err string) {
+	m.errMsg = err
+	m.running = false
+}
+
+// SetVisible sets the visibility
+func (m *WIQLEditor) SetVisible(visible bool) {
+	m.visible = visible
+	if !visible {
+		m.running = false
+		m.textarea.Blur()
+	}
+}
+
+// IsRunning returns whether the editor waits for the query it ran
+func (m *WIQLEditor) IsRunning() bool {
+	return m.visible && m.running
+}
+
+// IsVisible returns whether the modal is visible
+func (m *WIQLEditor) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal container size
+func (m *WIQLEditor) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// EditWIQLInEditorMsg is sent when the query should be continued in $EDITOR
+type EditWIQLInEditorMsg struct {
+	Text string
+}
+
+// WIQLClearMsg is sent when the list should go back to the filters
+type WIQLClearMsg struct{}
+
+// WIQLRunMsg is sent when the user runs a WIQL query
+type WIQLRunMsg struct {
+	Query string
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 	"github.com/samuelenocsson/devops-tui/internal/models"
 	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
 )
@@ -60,14 +61,44 @@ type WorkItemsPanel struct {
 	columns   []column
 	sortField SortField
 	sortDir   SortDirection
+
+	// Multi-select, by work item ID so marks survive sorting and reloads
+	marked map[int]bool
+	anchor int // ID of the item last toggled, start of range selection
+
+	// Tree mode lists the items as a parent-child hierarchy; items then holds
+	// the rows of expanded nodes only
+	tree      bool
+	links     []models.HierarchyLink
+	roots     []*models.HierarchyNode
+	treeRows  map[int]treeRow
+	collapsed map[int]bool // By work item ID, kept across reloads
+
+	// Changes found by background refreshes, by work item ID. Removed items stay
+	// listed until the changes are cleared.
+	changes map[int]models.ItemChange
+	removed map[int]models.WorkItem
+}
+
+// treeRow is the place of a work item in the hierarchy
+type treeRow struct {
+	node     *models.HierarchyNode
+	depth    int
+	parentID int // 0 for a top-level item
+	rollup   models.HierarchyRollup
 }
 
 // NewWorkItemsPanel creates a new work items panel
 func NewWorkItemsPanel(styles theme.Styles, keys theme.KeyMap) WorkItemsPanel {
 	return WorkItemsPanel{
-		items:  []models.WorkItem{},
-		styles: styles,
-		keys:   keys,
+		items:     []models.WorkItem{},
+		marked:    make(map[int]bool),
+		treeRows:  make(map[int]treeRow),
+		collapsed: make(map[int]bool),
+		changes:   make(map[int]models.ItemChange),
+		removed:   make(map[int]models.WorkItem),
+		styles:    styles,
+		keys:      keys,
 		columns: []column{
 			{title: "ID", width: 10, minWidth: 10},    // #12345678 - never truncate
 			{title: "TYPE", width: 8, minWidth: 8},    // Feature, PBI, etc - never truncate
@@ -145,6 +176,36 @@ func (w WorkItemsPanel) Update(msg tea.Msg) (WorkItemsPanel, tea.Cmd) {
 			if w.SelectedItem() != nil {
 				return w, func() tea.Msg { return ViewWorkItemMsg{Item: *w.SelectedItem()} }
 			}
+		case key.Matches(msg, w.keys.ToggleMark):
+			if item := w.SelectedItem(); item != nil {
+				if w.marked[item.ID] {
+					delete(w.marked, item.ID)
+				} else {
+					w.marked[item.ID] = true
+				}
+				w.anchor = item.ID
+				w.moveDown()
+			}
+		case key.Matches(msg, w.keys.MarkRange):
+			w.markRange()
+		case key.Matches(msg, w.keys.MarkAll):
+			if len(w.marked) == len(w.items) {
+				w.ClearMarks()
+			} else {
+				for _, item := range w.items {
+					w.marked[item.ID] = true
+				}
+			}
+		case key.Matches(msg, w.keys.Back):
+			w.ClearMarks()
+		case key.Matches(msg, w.keys.TreeView):
+			w.setTreeMode(!w.tree)
+			enabled := w.tree
+			return w, func() tea.Msg { return TreeModeChangedMsg{Enabled: enabled} }
+		case w.tree && key.Matches(msg, w.keys.Left):
+			w.collapse()
+		case w.tree && key.Matches(msg, w.keys.Right):
+			w.expand()
 		case key.Matches(msg, w.keys.SortByID):
 			w.toggleSort(SortByID)
 		case key.Matches(msg, w.keys.SortByState):
@@ -169,8 +230,12 @@ func (w WorkItemsPanel) View() string {
 	b.WriteString(header)
 	b.WriteString("\n")
 
-	// Separator line
+	// Separator line, replaced by the selection count while items are marked
 	separator := w.renderSeparator(colWidths)
+	if len(w.marked) > 0 {
+		separator = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F59E0B")).
+			Render(fmt.Sprintf("%d selected  (space toggle, V range, ctrl+a all, B bulk actions, Esc clear)", len(w.marked)))
+	}
 	b.WriteString(separator)
 	b.WriteString("\n")
 
@@ -296,11 +361,18 @@ func (w *WorkItemsPanel) renderSeparator(colWidths []int) string {
 }
 
 func (w *WorkItemsPanel) renderItem(item models.WorkItem, isCursor bool, colWidths []int) string {
-	// Cursor indicator
+	// Cursor and selection indicator
 	cursor := "  "
 	if isCursor {
 		cursor = "▸ "
 	}
+	if w.marked[item.ID] {
+		cursor = cursor[:len(cursor)-1] + "●"
+	}
+	change, changed := w.changes[item.ID]
+	if changed && !isCursor {
+		cursor = changeMarker(change.Kind) + cursor[1:]
+	}
 
 	// Format values - ID, TYPE, STATE never truncated; ASSIGNED and TITLE can be
 	id := fmt.Sprintf("#%d", item.ID)
@@ -311,7 +383,9 @@ func (w *WorkItemsPanel) renderItem(item models.WorkItem, isCursor bool, colWidt
 		assigned = "-"
 	}
 	assigned = truncateStr(assigned, colWidths[3])
-	title := truncateStr(item.Title, colWidths[4])
+	prefix, suffix := w.treeDecoration(item.ID)
+	titleWidth := colWidths[4] - runewidth.StringWidth(prefix) - runewidth.StringWidth(suffix)
+	title := truncateStr(item.Title, titleWidth)
 
 	// For cursor row, use plain text with unified background
 	if isCursor {
@@ -328,7 +402,7 @@ func (w *WorkItemsPanel) renderItem(item models.WorkItem, isCursor bool, colWidt
 			padRight(typeStr, colWidths[1]),
 			padRight(stateStr, colWidths[2]),
 			padRight(assigned, colWidths[3]),
-			padRight(title, colWidths[4]),
+			prefix + padRight(title+suffix, colWidths[4]-runewidth.StringWidth(prefix)),
 		}
 		row := cursor + strings.Join(cells, "  ")
 		return rowStyle.Render(row)
@@ -340,6 +414,13 @@ func (w *WorkItemsPanel) renderItem(item models.WorkItem, isCursor bool, colWidt
 	stateStyle := w.styles.StateBadge(string(item.State))
 	assignedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
 	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F9FAFB"))
+	treeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	if changed && change.Kind == models.ItemRemoved {
+		// Dim the whole row of an item that is no longer in the results
+		muted := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		idStyle, typeStyle, stateStyle, assignedStyle = muted, muted, muted, muted
+		titleStyle = muted.Strikethrough(true)
+	}
 
 	// Build cells with padRight for alignment, then apply color
 	cells := []string{
@@ -347,13 +428,52 @@ func (w *WorkItemsPanel) renderItem(item models.WorkItem, isCursor bool, colWidt
 		typeStyle.Render(padRight(typeStr, colWidths[1])),
 		stateStyle.Render(padRight(stateStr, colWidths[2])),
 		assignedStyle.Render(padRight(assigned, colWidths[3])),
-		titleStyle.Render(padRight(title, colWidths[4])),
+		treeStyle.Render(prefix) + titleStyle.Render(title) + treeStyle.Render(suffix),
 	}
 
 	row := cursor + strings.Join(cells, "  ")
 	return row
 }
 
+// changeMarker returns the marker shown before a row changed by a background refresh
+func changeMarker(kind models.ItemChangeKind) string {
+	switch kind {
+	case models.ItemAdded:
+		return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#10B981")).Render("+")
+	case models.ItemRemoved:
+		return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#EF4444")).Render("-")
+	}
+	return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F59E0B")).Render("~")
+}
+
+// treeDecoration returns the indentation and expand marker shown before a title in
+// tree mode, and the rollup of the item's children shown after it
+func (w *WorkItemsPanel) treeDecoration(id int) (string, string) {
+	row, ok := w.treeRows[id]
+	if !w.tree || !ok {
+		return "", ""
+	}
+
+	prefix := strings.Repeat("  ", row.depth)
+	if row.rollup.Children == 0 {
+		return prefix + "  ", ""
+	}
+	if w.collapsed[id] {
+		prefix += "▹ "
+	} else {
+		prefix += "▾ "
+	}
+
+	parts := []string{fmt.Sprintf("%d", row.rollup.Children)}
+	if row.rollup.Points > 0 {
+		parts = append(parts, models.FormatPoints(row.rollup.Points)+" pts")
+	}
+	if row.rollup.RemainingWork > 0 {
+		parts = append(parts, models.FormatPoints(row.rollup.RemainingWork)+"h")
+	}
+	return prefix, " [" + strings.Join(parts, " · ") + "]"
+}
+
 func padRight(s string, width int) string {
 	if len(s) >= width {
 		return s
@@ -448,6 +568,27 @@ func (w *WorkItemsPanel) adjustOffset() {
 	}
 }
 
+// markRange marks all items between the last toggled item and the cursor
+func (w *WorkItemsPanel) markRange() {
+	from := -1
+	for i, item := range w.items {
+		if item.ID == w.anchor {
+			from = i
+			break
+		}
+	}
+	if from < 0 || w.cursor >= len(w.items) {
+		return
+	}
+	to := w.cursor
+	if from > to {
+		from, to = to, from
+	}
+	for i := from; i <= to; i++ {
+		w.marked[w.items[i].ID] = true
+	}
+}
+
 func (w *WorkItemsPanel) toggleSort(field SortField) {
 	if w.sortField == field {
 		// Toggle direction if same field
@@ -464,28 +605,138 @@ func (w *WorkItemsPanel) toggleSort(field SortField) {
 }
 
 func (w *WorkItemsPanel) sortItems() {
+	if w.tree {
+		// Sort siblings, keeping children under their parent
+		w.sortNodes(w.roots)
+		w.flattenTree()
+		return
+	}
+
 	if len(w.items) == 0 {
 		return
 	}
 
 	sort.SliceStable(w.items, func(i, j int) bool {
-		var less bool
-		switch w.sortField {
-		case SortByID:
-			less = w.items[i].ID < w.items[j].ID
-		case SortByState:
-			less = string(w.items[i].State) < string(w.items[j].State)
-		case SortByType:
-			less = string(w.items[i].Type) < string(w.items[j].Type)
-		default:
-			less = w.items[i].ID < w.items[j].ID
+		return w.less(&w.items[i], &w.items[j])
+	})
+}
+
+// less reports whether a sorts before b in the current sort order
+func (w *WorkItemsPanel) less(a, b *models.WorkItem) bool {
+	var less bool
+	switch w.sortField {
+	case SortByID:
+		less = a.ID < b.ID
+	case SortByState:
+		less = string(a.State) < string(b.State)
+	case SortByType:
+		less = string(a.Type) < string(b.Type)
+	default:
+		less = a.ID < b.ID
+	}
+
+	if w.sortDir == SortDesc {
+		return !less
+	}
+	return less
+}
+
+// sortNodes sorts tree nodes and, recursively, their children
+func (w *WorkItemsPanel) sortNodes(nodes []*models.HierarchyNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return w.less(&nodes[i].Item, &nodes[j].Item)
+	})
+	for _, node := range nodes {
+		w.sortNodes(node.Children)
+	}
+}
+
+// buildTree arranges items into the hierarchy of the current links
+func (w *WorkItemsPanel) buildTree(items []models.WorkItem) {
+	w.roots = models.BuildHierarchy(items, w.links)
+	w.treeRows = make(map[int]treeRow, len(items))
+
+	var walk func(nodes []*models.HierarchyNode, depth, parentID int)
+	walk = func(nodes []*models.HierarchyNode, depth, parentID int) {
+		for _, node := range nodes {
+			w.treeRows[node.Item.ID] = treeRow{node: node, depth: depth, parentID: parentID, rollup: node.Rollup()}
+			walk(node.Children, depth+1, node.Item.ID)
+		}
+	}
+	walk(w.roots, 0, 0)
+}
+
+// flattenTree lists the items of the tree whose ancestors are all expanded
+func (w *WorkItemsPanel) flattenTree() {
+	var items []models.WorkItem
+	var walk func(nodes []*models.HierarchyNode)
+	walk = func(nodes []*models.HierarchyNode) {
+		for _, node := range nodes {
+			items = append(items, node.Item)
+			if !w.collapsed[node.Item.ID] {
+				walk(node.Children)
+			}
 		}
+	}
+	walk(w.roots)
+	w.items = items
+}
 
-		if w.sortDir == SortDesc {
-			return !less
+// treeItems returns all items of the tree, including those of collapsed nodes
+func (w *WorkItemsPanel) treeItems() []models.WorkItem {
+	var items []models.WorkItem
+	var walk func(nodes []*models.HierarchyNode)
+	walk = func(nodes []*models.HierarchyNode) {
+		for _, node := range nodes {
+			items = append(items, node.Item)
+			walk(node.Children)
 		}
-		return less
-	})
+	}
+	walk(w.roots)
+	return items
+}
+
+// setTreeMode switches between the flat list and the tree of the current items
+func (w *WorkItemsPanel) setTreeMode(tree bool) {
+	if tree == w.tree {
+		return
+	}
+	items := w.items
+	if w.tree {
+		items = w.treeItems()
+		w.links = nil
+	}
+	w.tree = tree
+	w.SetItems(items)
+}
+
+// collapse hides the children of the selected item, or selects its parent if it
+// has none or is already collapsed
+func (w *WorkItemsPanel) collapse() {
+	item := w.SelectedItem()
+	if item == nil {
+		return
+	}
+	row := w.treeRows[item.ID]
+	if row.rollup.Children > 0 && !w.collapsed[item.ID] {
+		w.collapsed[item.ID] = true
+		w.flattenTree()
+		return
+	}
+	for i := range w.items {
+		if row.parentID != 0 && w.items[i].ID == row.parentID {
+			w.cursor = i
+			w.adjustOffset()
+		}
+	}
+}
+
+// expand shows the children of the selected item
+func (w *WorkItemsPanel) expand() {
+	if item := w.SelectedItem(); item != nil && w.collapsed[item.ID] {
+		delete(w.collapsed, item.ID)
+		w.flattenTree()
+	}
 }
 
 // SetSize sets the size of the work items panel
@@ -520,7 +771,38 @@ func (w *WorkItemsPanel) SetItems(items []models.WorkItem) {
 	}
 
 	oldLen := len(w.items)
+
+	// Keep listing the items a background refresh found removed
+	listed := make(map[int]bool, len(items))
+	for _, item := range items {
+		listed[item.ID] = true
+	}
+	items = items[:len(items):len(items)] // Append to a copy, not the caller's array
+	for id, item := range w.removed {
+		if listed[id] {
+			delete(w.removed, id)
+			continue
+		}
+		items = append(items, item)
+		listed[id] = true
+	}
+
 	w.items = items
+	if w.tree {
+		w.buildTree(items)
+	}
+
+	// Drop marks and changes of items that are no longer listed
+	for id := range w.changes {
+		if !listed[id] {
+			delete(w.changes, id)
+		}
+	}
+	for id := range w.marked {
+		if !listed[id] {
+			delete(w.marked, id)
+		}
+	}
 
 	// Re-apply current sort
 	w.sortItems()
@@ -540,8 +822,8 @@ func (w *WorkItemsPanel) SetItems(items []models.WorkItem) {
 	}
 
 	// Clamp cursor to valid range
-	if w.cursor >= len(items) {
-		w.cursor = len(items) - 1
+	if w.cursor >= len(w.items) {
+		w.cursor = len(w.items) - 1
 	}
 	if w.cursor < 0 {
 		w.cursor = 0
@@ -557,6 +839,126 @@ func (w *WorkItemsPanel) SetItems(items []models.WorkItem) {
 	}
 }
 
+// AddItem inserts a work item into the list and moves the cursor to it
+func (w *WorkItemsPanel) AddItem(item models.WorkItem) {
+	if w.tree {
+		// Place it under its parent if that is listed, and show it
+		node := &models.HierarchyNode{Item: item}
+		if parent, ok := w.treeRows[item.ParentID]; ok {
+			parent.node.Children = append(parent.node.Children, node)
+			w.treeRows[item.ID] = treeRow{node: node, depth: parent.depth + 1, parentID: item.ParentID}
+			// Every ancestor's rollup counts the new item
+			for id := item.ParentID; id != 0; {
+				row, ok := w.treeRows[id]
+				if !ok {
+					break
+				}
+				row.rollup = row.node.Rollup()
+				w.treeRows[id] = row
+				delete(w.collapsed, id)
+				id = row.parentID
+			}
+		} else {
+			w.roots = append(w.roots, node)
+			w.treeRows[item.ID] = treeRow{node: node}
+		}
+	} else {
+		w.items = append(w.items, item)
+	}
+	w.sortItems()
+
+	for i := range w.items {
+		if w.items[i].ID == item.ID {
+			w.cursor = i
+			break
+		}
+	}
+	w.adjustOffset()
+}
+
+// UpdateItem applies fn to the work item with the given ID, if it is in the list
+func (w *WorkItemsPanel) UpdateItem(id int, fn func(item *models.WorkItem)) {
+	if row, ok := w.treeRows[id]; ok && w.tree {
+		fn(&row.node.Item)
+	}
+	for i := range w.items {
+		if w.items[i].ID == id {
+			fn(&w.items[i])
+			return
+		}
+	}
+}
+
+// AddChanges highlights the changes a background refresh found, adding to those
+// already shown. Call it before SetItems with the refreshed items; removed holds the
+// items that dropped out of the results.
+func (w *WorkItemsPanel) AddChanges(changes map[int]models.ItemChange, removed []models.WorkItem) {
+	for id, change := range changes {
+		if prev, ok := w.changes[id]; ok {
+			switch {
+			case prev.Kind == models.ItemAdded && change.Kind == models.ItemUpdated:
+				// Still new to the list
+				change.Kind = models.ItemAdded
+				change.Fields = nil
+			case prev.Kind == models.ItemUpdated && change.Kind == models.ItemUpdated:
+				change.Fields = append(append([]string{}, prev.Fields...), change.Fields...)
+			}
+		}
+		w.changes[id] = change
+	}
+	for _, item := range removed {
+		w.removed[item.ID] = item
+	}
+}
+
+// ClearChanges removes the highlights of background refreshes. Removed items stay
+// listed until the next SetItems.
+func (w *WorkItemsPanel) ClearChanges() {
+	w.changes = make(map[int]models.ItemChange)
+	w.removed = make(map[int]models.WorkItem)
+}
+
+// SelectedChange returns what a background refresh found changed about the
+// selected work item, or nil
+func (w *WorkItemsPanel) SelectedChange() *models.ItemChange {
+	item := w.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	if change, ok := w.changes[item.ID]; ok {
+		return &change
+	}
+	return nil
+}
+
+// MarkedItems returns the marked work items in list order
+func (w *WorkItemsPanel) MarkedItems() []models.WorkItem {
+	var items []models.WorkItem
+	for _, item := range w.items {
+		if w.marked[item.ID] {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ClearMarks unmarks all work items
+func (w *WorkItemsPanel) ClearMarks() {
+	w.marked = make(map[int]bool)
+	w.anchor = 0
+}
+
+// SetHierarchy sets the parent-child links used to arrange the items in tree mode.
+// Call it before SetItems.
+func (w *WorkItemsPanel) SetHierarchy(links []models.HierarchyLink) {
+	w.links = links
+}
+
+// IsTreeMode returns whether the items are shown as a tree
+func (w *WorkItemsPanel) IsTreeMode() bool {
+	return w.tree
+}
+
 // SelectedItem returns the currently selected work item
 func (w *WorkItemsPanel) SelectedItem() *models.WorkItem {
 	if w.cursor >= 0 && w.cursor < len(w.items) {
@@ -574,3 +976,8 @@ type OpenWorkItemMsg struct {
 type ViewWorkItemMsg struct {
 	Item models.WorkItem
 }
+
+// TreeModeChangedMsg is sent when the list switches between flat and tree mode
+type TreeModeChangedMsg struct {
+	Enabled bool
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/samuelenocsson/devops-tui/internal/api"
+)
+
+// describeError turns an API error into a short human message with a recovery hint
+func describeError(err error) string {
+	var (
+		unauthorized *api.UnauthorizedError
+		forbidden    *api.ForbiddenError
+		notFound     *api.NotFoundError
+		ruleErr      *api.RuleValidationError
+		conflict     *api.RevisionConflictError
+		throttled    *api.ThrottledError
+		apiErr       *api.APIError
+	)
+
+	switch {
+	case api.IsOffline(err):
+		return "Can't reach Azure DevOps - check your connection (Ctrl+r to retry)"
+	case errors.As(err, &unauthorized):
+		return "Not signed in or token expired - run 'devops-tui login' or check your PAT"
+	case errors.As(err, &forbidden):
+		return "Permission denied - your account or PAT lacks access to this resource"
+	case errors.As(err, &notFound):
+		return "Not found - it may have been deleted or moved (Ctrl+r to reload)"
+	case errors.As(err, &ruleErr):
+		if ruleErr.Field != "" {
+			return fmt.Sprintf("Invalid value for %s: %s - fix the field and try again", ruleErr.Field, ruleErr.Message)
+		}
+		return fmt.Sprintf("Rejected by process rules: %s", ruleErr.Message)
+	case errors.As(err, &conflict):
+		return "Work item was changed by someone else - Ctrl+r to reload"
+	case errors.As(err, &throttled):
+		if throttled.RetryAfter > 0 {
+			return fmt.Sprintf("Throttled by Azure DevOps - try again in %.0fs", throttled.RetryAfter.Seconds())
+		}
+		return "Throttled by Azure DevOps - try again shortly"
+	case errors.As(err, &apiErr):
+		return apiErr.Error()
+	}
+
+	return err.Error()
+}
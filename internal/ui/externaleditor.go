@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/samuelenocsson/devops-tui/internal/api"
+	"github.com/samuelenocsson/devops-tui/internal/ui/components"
+	"github.com/samuelenocsson/devops-tui/pkg/editor"
+	"github.com/samuelenocsson/devops-tui/pkg/markup"
+)
+
+// editInExternalEditor suspends the program, opens content in $VISUAL/$EDITOR as a
+// markdown file and passes the saved text to done once the editor exits
+func editInExternalEditor(content string, done func(text string, err error) tea.Msg) tea.Cmd {
+	path, err := editor.CreateTemp("devops-tui-*.md", content)
+	if err != nil {
+		return func() tea.Msg { return done("", err) }
+	}
+
+	return tea.ExecProcess(editor.Cmd(path), func(err error) tea.Msg {
+		if err != nil {
+			os.Remove(path)
+			return done("", fmt.Errorf("running %s: %w", editor.Command(), err))
+		}
+		text, err := editor.ReadAndRemove(path)
+		return done(text, err)
+	})
+}
+
+// loadLongTextCmd fetches the HTML of a rich text field and converts it to markdown
+// for editing
+func loadLongTextCmd(client *api.Client, req components.EditLongTextMsg) tea.Cmd {
+	return func() tea.Msg {
+		values, err := client.GetWorkItemRawFields(req.Item.ID, []string{req.Field})
+		if err != nil {
+			return editorErrMsg{err: err}
+		}
+		return longTextLoadedMsg{
+			field:    req.Field,
+			label:    req.Label,
+			markdown: markup.HTMLToMarkdown(values[req.Field]),
+		}
+	}
+}
+
+// editLongTextCmd opens a rich text field's markdown in the external editor
+func editLongTextCmd(field, label, markdown string) tea.Cmd {
+	return editInExternalEditor(markdown, func(text string, err error) tea.Msg {
+		if err != nil {
+			return editorErrMsg{err: err}
+		}
+		return longTextEditedMsg{field: field, label: label, original: markdown, markdown: text}
+	})
+}
+
+// longTextLoadedMsg carries a rich text field converted to markdown
+type longTextLoadedMsg struct {
+	field    string
+	label    string
+	markdown string
+}
+
+// longTextEditedMsg carries a rich text field after editing in $EDITOR
+type longTextEditedMsg struct {
+	field    string
+	label    string
+	original string
+	markdown string
+}
+
+// editorErrMsg is sent when external editing failed; it is shown in the open modal
+type editorErrMsg struct {
+	err error
+}
+
+// commentComposedMsg carries a comment after editing in $EDITOR
+type commentComposedMsg struct {
+	text string
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/samuelenocsson/devops-tui/internal/api"
+	"github.com/samuelenocsson/devops-tui/internal/auth"
+	"github.com/samuelenocsson/devops-tui/internal/config"
+	"github.com/samuelenocsson/devops-tui/internal/ui/components"
+)
+
+// canSignIn reports whether err is fixed by signing in again, which only helps
+// with the device flow; a rejected PAT has to be replaced in the config
+func canSignIn(err error, cfg *config.Config) bool {
+	var unauthorized *api.UnauthorizedError
+	return errors.As(err, &unauthorized) && cfg.AuthMethod == config.AuthMethodOAuth
+}
+
+// signInCmd suspends the program, runs the device flow of the login command and
+// picks up the new token once it exits
+func signInCmd() tea.Cmd {
+	exe, err := os.Executable()
+	if err != nil {
+		return func() tea.Msg { return signedInMsg{err: fmt.Errorf("locating devops-tui: %w", err)} }
+	}
+
+	return tea.ExecProcess(exec.Command(exe, "login"), func(err error) tea.Msg {
+		if err != nil {
+			return signedInMsg{err: fmt.Errorf("signing in: %w", err)}
+		}
+		token, err := auth.NewDeviceFlowAuthenticator().GetToken()
+		if err != nil {
+			return signedInMsg{err: fmt.Errorf("signing in: %w", err)}
+		}
+		return signedInMsg{token: token}
+	})
+}
+
+// signedInMsg carries the new access token after signing in again
+type signedInMsg struct {
+	token string
+	err   error
+}
+
+// updateErrMsg is sent when a field update failed for another reason than a
+// revision conflict
+type updateErrMsg struct {
+	id      int
+	changes []components.FieldChange
+	err     error
+}
@@ -20,12 +20,55 @@ type KeyMap struct {
 	View         key.Binding
 	Search       key.Binding
 	Refresh      key.Binding
+	SignIn       key.Binding
 	Help         key.Binding
 	Back         key.Binding
 	Quit         key.Binding
 	ChangeState  key.Binding
 	CreateBranch key.Binding
 	Assign       key.Binding
+	EditFields   key.Binding
+	Save         key.Binding
+	NewWorkItem  key.Binding
+	History      key.Binding
+	MoveToSprint key.Binding
+	WIQLQuery    key.Binding
+
+	// Multi-select (work items list)
+	ToggleMark  key.Binding
+	MarkRange   key.Binding
+	MarkAll     key.Binding
+	BulkActions key.Binding
+
+	// Views
+	SprintPlanning  key.Binding
+	SprintDashboard key.Binding
+	Velocity        key.Binding
+	CumulativeFlow  key.Binding
+	CycleTime       key.Binding
+	KanbanBoard     key.Binding
+	Taskboard       key.Binding
+	TreeView        key.Binding
+
+	// Comments (detail view)
+	AddComment    key.Binding
+	EditComment   key.Binding
+	DeleteComment key.Binding
+	NextComment   key.Binding
+	PrevComment   key.Binding
+
+	// Links (detail view)
+	ManageLinks       key.Binding
+	ManageAttachments key.Binding
+
+	// Development links (detail view)
+	NextDevLink key.Binding
+	OpenDevLink key.Binding
+
+	// Conflict modal
+	ConflictRetry   key.Binding
+	ConflictMerge   key.Binding
+	ConflictDiscard key.Binding
 
 	// Sorting
 	SortByID    key.Binding
@@ -88,6 +131,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("ctrl+r"),
 			key.WithHelp("Ctrl+r", "refresh"),
 		),
+		SignIn: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "sign in again"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
@@ -112,6 +159,126 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("a"),
 			key.WithHelp("a", "assign"),
 		),
+		EditFields: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit fields"),
+		),
+		Save: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("Ctrl+s", "save fields"),
+		),
+		NewWorkItem: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "new work item"),
+		),
+		History: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "history"),
+		),
+		MoveToSprint: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "move to sprint"),
+		),
+		WIQLQuery: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "WIQL query"),
+		),
+		SprintPlanning: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "sprint planning"),
+		),
+		SprintDashboard: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "sprint dashboard"),
+		),
+		Velocity: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "velocity"),
+		),
+		CumulativeFlow: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "cumulative flow"),
+		),
+		CycleTime: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "cycle time"),
+		),
+		KanbanBoard: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "kanban board"),
+		),
+		Taskboard: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "taskboard"),
+		),
+		TreeView: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "tree view"),
+		),
+		ToggleMark: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("Space", "mark item"),
+		),
+		MarkRange: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "mark range"),
+		),
+		MarkAll: key.NewBinding(
+			key.WithKeys("ctrl+a"),
+			key.WithHelp("Ctrl+a", "mark all"),
+		),
+		BulkActions: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "bulk actions"),
+		),
+		AddComment: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "add comment"),
+		),
+		EditComment: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit comment"),
+		),
+		DeleteComment: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "delete comment"),
+		),
+		NextComment: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "next comment"),
+		),
+		PrevComment: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "previous comment"),
+		),
+		ManageLinks: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "manage links"),
+		),
+		ManageAttachments: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "attachments"),
+		),
+		NextDevLink: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "next PR/commit/branch"),
+		),
+		OpenDevLink: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open PR/commit/branch"),
+		),
+		ConflictRetry: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "retry (overwrite)"),
+		),
+		ConflictMerge: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "merge (keep theirs on !)"),
+		),
+		ConflictDiscard: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "discard"),
+		),
 		SortByID: key.NewBinding(
 			key.WithKeys("1"),
 			key.WithHelp("1", "sort by ID"),
@@ -144,9 +311,12 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		{k.Up, k.Down, k.Top, k.Bottom},
 		{k.NextPanel, k.PrevPanel},
 		{k.Select, k.Open, k.View},
-		{k.ChangeState, k.CreateBranch, k.Assign},
+		{k.ChangeState, k.CreateBranch, k.Assign, k.EditFields, k.Save, k.NewWorkItem, k.History, k.MoveToSprint, k.WIQLQuery},
+		{k.SprintPlanning, k.SprintDashboard, k.Velocity, k.CumulativeFlow, k.CycleTime, k.KanbanBoard, k.Taskboard, k.TreeView},
+		{k.ToggleMark, k.MarkRange, k.MarkAll, k.BulkActions},
+		{k.AddComment, k.EditComment, k.DeleteComment, k.NextComment, k.PrevComment, k.ManageLinks, k.ManageAttachments, k.NextDevLink, k.OpenDevLink},
 		{k.SortByID, k.SortByType, k.SortByState},
-		{k.Search, k.Refresh},
+		{k.Search, k.Refresh, k.SignIn},
 		{k.Help, k.Back, k.Quit},
 	}
 }
@@ -17,6 +17,9 @@ func main() {
 		case "login":
 			cmd.ExecuteLogin()
 			return
+		case "report":
+			cmd.ExecuteReport(os.Args[2:])
+			return
 		}
 	}
 
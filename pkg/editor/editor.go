@@ -0,0 +1,59 @@
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Command returns the user's preferred editor from $VISUAL or $EDITOR,
+// falling back to a platform default
+func Command() string {
+	if e := strings.TrimSpace(os.Getenv("VISUAL")); e != "" {
+		return e
+	}
+	if e := strings.TrimSpace(os.Getenv("EDITOR")); e != "" {
+		return e
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// Cmd returns a command that opens path in the user's editor. Editor settings
+// with arguments, like "code --wait", are supported.
+func Cmd(path string) *exec.Cmd {
+	parts := strings.Fields(Command())
+	args := append(parts[1:], path)
+	return exec.Command(parts[0], args...)
+}
+
+// CreateTemp writes content to a new temporary file and returns its path.
+// pattern is passed to os.CreateTemp, e.g. "devops-tui-*.md".
+func CreateTemp(pattern, content string) (string, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(content); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("writing temp file: %w", err)
+	}
+	return f.Name(), nil
+}
+
+// ReadAndRemove reads the edited file and deletes it
+func ReadAndRemove(path string) (string, error) {
+	defer os.Remove(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading edited file: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,318 @@
+// Package markup converts between the HTML stored in Azure DevOps rich text
+// fields and markdown for editing in a text editor.
+package markup
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"golang.org/x/net/html"
+)
+
+var md = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// MarkdownToHTML renders markdown to HTML
+func MarkdownToHTML(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(source), &buf); err != nil {
+		return "", fmt.Errorf("rendering markdown: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+var (
+	blankLinesRe = regexp.MustCompile(`\n{3,}`)
+	whitespaceRe = regexp.MustCompile(`\s+`)
+)
+
+// HTMLToMarkdown converts rich text HTML to markdown. Formatting markdown can't
+// express is dropped, keeping the text.
+func HTMLToMarkdown(source string) string {
+	doc, err := html.Parse(strings.NewReader(source))
+	if err != nil {
+		return source
+	}
+
+	c := &converter{}
+	c.children(doc)
+
+	out := blankLinesRe.ReplaceAllString(c.buf.String(), "\n\n")
+	lines := strings.Split(out, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		// A hard line break before a blank line or the end is just noise
+		if strings.HasSuffix(line, "\\") && (i == len(lines)-1 || strings.TrimSpace(lines[i+1]) == "") {
+			line = strings.TrimSuffix(line, "\\")
+		}
+		lines[i] = line
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// converter walks an HTML tree writing markdown
+type converter struct {
+	buf    strings.Builder
+	lists  []listState // Enclosing lists, innermost last
+	inPre  bool
+	prefix string // Line prefix for block quotes
+}
+
+type listState struct {
+	ordered bool
+	index   int
+}
+
+func (c *converter) children(n *html.Node) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.node(child)
+	}
+}
+
+// block ensures the following output starts on a new paragraph
+func (c *converter) block() {
+	s := c.buf.String()
+	switch {
+	case s == "":
+	case strings.HasSuffix(s, "\n\n"):
+	case strings.HasSuffix(s, "\n"):
+		c.buf.WriteString(c.prefix + "\n")
+	default:
+		c.buf.WriteString("\n" + c.prefix + "\n")
+	}
+}
+
+// newline ends the current line
+func (c *converter) newline() {
+	s := c.buf.String()
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		c.buf.WriteString("\n")
+	}
+}
+
+func (c *converter) atLineStart() bool {
+	s := c.buf.String()
+	return s == "" || strings.HasSuffix(s, "\n")
+}
+
+func (c *converter) text(s string) {
+	if c.inPre {
+		c.buf.WriteString(s)
+		return
+	}
+
+	// Collapse whitespace like a browser would
+	s = whitespaceRe.ReplaceAllString(s, " ")
+	if c.atLineStart() {
+		s = strings.TrimLeft(s, " ")
+		if s == "" {
+			return
+		}
+		c.buf.WriteString(c.prefix)
+	} else if strings.HasSuffix(c.buf.String(), " ") {
+		s = strings.TrimLeft(s, " ")
+	}
+	c.buf.WriteString(s)
+}
+
+// inline wraps the children of n in a markdown delimiter
+func (c *converter) inline(n *html.Node, delim string) {
+	var inner converter
+	inner.inPre = c.inPre
+	inner.children(n)
+	raw := inner.buf.String()
+	text := strings.TrimSpace(raw)
+	if text == "" {
+		c.text(raw)
+		return
+	}
+	// Keep surrounding spaces outside the delimiters, where markdown needs them
+	if strings.HasPrefix(raw, " ") {
+		c.text(" ")
+	}
+	c.text(delim + text + delim)
+	if strings.HasSuffix(raw, " ") {
+		c.text(" ")
+	}
+}
+
+func (c *converter) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		c.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		c.children(n)
+		return
+	}
+
+	switch n.Data {
+	case "p", "div":
+		c.block()
+		c.children(n)
+		c.block()
+	case "br":
+		if c.inPre {
+			c.buf.WriteString("\n")
+		} else if c.atLineStart() {
+			// Empty line used as spacing
+			c.block()
+		} else {
+			// A trailing backslash is a markdown hard line break
+			c.buf.WriteString("\\\n")
+		}
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		c.block()
+		c.buf.WriteString(c.prefix + strings.Repeat("#", int(n.Data[1]-'0')) + " ")
+		c.children(n)
+		c.block()
+	case "strong", "b":
+		c.inline(n, "**")
+	case "em", "i":
+		c.inline(n, "*")
+	case "del", "s", "strike":
+		c.inline(n, "~~")
+	case "code":
+		if c.inPre {
+			c.children(n)
+		} else {
+			c.inline(n, "`")
+		}
+	case "pre":
+		c.block()
+		c.buf.WriteString("```\n")
+		c.inPre = true
+		c.children(n)
+		c.inPre = false
+		c.newline()
+		c.buf.WriteString("```\n")
+		c.block()
+	case "a":
+		href := attr(n, "href")
+		var inner converter
+		inner.children(n)
+		label := strings.TrimSpace(inner.buf.String())
+		switch {
+		case href == "":
+			c.text(label)
+		case label == "" || label == href:
+			c.text("<" + href + ">")
+		default:
+			c.text("[" + label + "](" + href + ")")
+		}
+	case "img":
+		c.text("![" + attr(n, "alt") + "](" + attr(n, "src") + ")")
+	case "ul", "ol":
+		if len(c.lists) == 0 {
+			c.block()
+		} else {
+			c.newline()
+		}
+		c.lists = append(c.lists, listState{ordered: n.Data == "ol"})
+		c.children(n)
+		c.lists = c.lists[:len(c.lists)-1]
+		if len(c.lists) == 0 {
+			c.block()
+		}
+	case "li":
+		c.newline()
+		marker := "- "
+		depth := len(c.lists)
+		if depth > 0 {
+			list := &c.lists[depth-1]
+			list.index++
+			if list.ordered {
+				marker = fmt.Sprintf("%d. ", list.index)
+			}
+		} else {
+			depth = 1
+		}
+		c.buf.WriteString(c.prefix + strings.Repeat("  ", depth-1) + marker)
+		c.children(n)
+		c.newline()
+	case "blockquote":
+		c.block()
+		saved := c.prefix
+		c.prefix += "> "
+		c.children(n)
+		c.prefix = saved
+		c.block()
+	case "hr":
+		c.block()
+		c.buf.WriteString("---\n")
+		c.block()
+	case "table":
+		c.block()
+		c.table(n)
+		c.block()
+	case "script", "style", "head":
+		// Not content
+	default:
+		c.children(n)
+	}
+}
+
+// table writes a GFM table. The first row is used as the header.
+func (c *converter) table(n *html.Node) {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "tr" {
+			var cells []string
+			for cell := n.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+					var inner converter
+					inner.children(cell)
+					text := strings.Join(strings.Fields(inner.buf.String()), " ")
+					cells = append(cells, strings.ReplaceAll(text, "|", `\|`))
+				}
+			}
+			rows = append(rows, cells)
+			return
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+
+	if len(rows) == 0 {
+		return
+	}
+	cols := 0
+	for _, row := range rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+
+	writeRow := func(cells []string) {
+		for len(cells) < cols {
+			cells = append(cells, "")
+		}
+		c.buf.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	writeRow(rows[0])
+	sep := make([]string, cols)
+	for i := range sep {
+		sep[i] = "---"
+	}
+	writeRow(sep)
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+}
+
+// attr returns the value of an attribute, or "" if not set
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
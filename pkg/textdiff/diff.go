@@ -0,0 +1,158 @@
+// Package textdiff computes line-based differences between two texts.
+package textdiff
+
+import "strings"
+
+// Op is the kind of a diff line
+type Op int
+
+const (
+	Equal Op = iota
+	Delete
+	Insert
+)
+
+// Line is a line of a diff
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// maxCells bounds the size of the comparison table; larger inputs are
+// reported as a full replacement
+const maxCells = 4_000_000
+
+// Lines returns the line differences turning oldText into newText, using the
+// longest common subsequence of lines
+func Lines(oldText, newText string) []Line {
+	a := splitLines(oldText)
+	b := splitLines(newText)
+
+	// Trim the common prefix and suffix to keep the table small
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var out []Line
+	for _, s := range a[:prefix] {
+		out = append(out, Line{Op: Equal, Text: s})
+	}
+	out = append(out, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, s := range a[len(a)-suffix:] {
+		out = append(out, Line{Op: Equal, Text: s})
+	}
+	return out
+}
+
+// diffMiddle diffs the lines between the common prefix and suffix
+func diffMiddle(a, b []string) []Line {
+	var out []Line
+	if len(a)*len(b) > maxCells {
+		for _, s := range a {
+			out = append(out, Line{Op: Delete, Text: s})
+		}
+		for _, s := range b {
+			out = append(out, Line{Op: Insert, Text: s})
+		}
+		return out
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, Line{Op: Equal, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, Line{Op: Delete, Text: a[i]})
+			i++
+		default:
+			out = append(out, Line{Op: Insert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, Line{Op: Delete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		out = append(out, Line{Op: Insert, Text: b[j]})
+	}
+	return out
+}
+
+// Pair is a row of a side-by-side diff. Old or New is empty when the row only
+// exists on one side.
+type Pair struct {
+	Old, New       string
+	OldOp, NewOp   Op
+	HasOld, HasNew bool
+}
+
+// SideBySide arranges diff lines in rows, pairing deleted lines with the
+// inserted lines that replace them
+func SideBySide(lines []Line) []Pair {
+	var pairs []Pair
+	for i := 0; i < len(lines); {
+		if lines[i].Op == Equal {
+			pairs = append(pairs, Pair{
+				Old: lines[i].Text, New: lines[i].Text,
+				HasOld: true, HasNew: true,
+			})
+			i++
+			continue
+		}
+
+		// Collect a run of changes, then pair deletions with insertions
+		var dels, ins []string
+		for i < len(lines) && lines[i].Op != Equal {
+			if lines[i].Op == Delete {
+				dels = append(dels, lines[i].Text)
+			} else {
+				ins = append(ins, lines[i].Text)
+			}
+			i++
+		}
+		for k := 0; k < len(dels) || k < len(ins); k++ {
+			p := Pair{OldOp: Delete, NewOp: Insert}
+			if k < len(dels) {
+				p.Old, p.HasOld = dels[k], true
+			}
+			if k < len(ins) {
+				p.New, p.HasNew = ins[k], true
+			}
+			pairs = append(pairs, p)
+		}
+	}
+	return pairs
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}